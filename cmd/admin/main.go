@@ -0,0 +1,82 @@
+// Command admin provides operator-facing maintenance tasks that don't
+// belong in the game server itself, such as GDPR deletion requests.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func main() {
+	dbPath := flag.String("db", "term-idle.db", "path to the SQLite database")
+	deletePlayer := flag.String("delete-player", "", "permanently delete the player with this ID and all their data")
+	migrateTarget := flag.String("migrate-target", "", "migrate the schema to this migration (e.g. 0003_sessions), rolling back later ones; leave unset to migrate to latest")
+	banValue := flag.String("ban", "", "block SSH connections matching this CIDR range or key fingerprint")
+	banKind := flag.String("ban-kind", "cidr", "kind of value passed to -ban: \"cidr\" or \"key\"")
+	banReason := flag.String("ban-reason", "", "reason recorded alongside -ban, shown in -list-bans")
+	unban := flag.String("unban", "", "lift a ban previously added with -ban")
+	listBans := flag.Bool("list-bans", false, "print every active connection ban")
+	auditLogUsername := flag.String("audit-log", "", "print recent SSH auth attempts for this username")
+	auditLogLimit := flag.Int("audit-log-limit", 20, "max entries printed by -audit-log")
+	flag.Parse()
+
+	if *deletePlayer == "" && *migrateTarget == "" && *banValue == "" && *unban == "" && !*listBans && *auditLogUsername == "" {
+		log.Fatal("no action specified; see -h for usage")
+	}
+
+	opts := db.DefaultOptions()
+	opts.MigrateTarget = *migrateTarget
+
+	database, err := db.NewSQLiteDB(*dbPath, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close()
+
+	if *migrateTarget != "" {
+		log.Printf("migrated schema to %s", *migrateTarget)
+	}
+
+	if *deletePlayer != "" {
+		if err := database.DeletePlayer(*deletePlayer); err != nil {
+			log.Fatalf("failed to delete player %s: %v", *deletePlayer, err)
+		}
+		log.Printf("deleted player %s", *deletePlayer)
+	}
+
+	if *banValue != "" {
+		if err := database.AddConnectionBan(*banValue, *banKind, *banReason); err != nil {
+			log.Fatalf("failed to add connection ban for %s: %v", *banValue, err)
+		}
+		log.Printf("banned %s (%s)", *banValue, *banKind)
+	}
+
+	if *unban != "" {
+		if err := database.RemoveConnectionBan(*unban); err != nil {
+			log.Fatalf("failed to remove connection ban for %s: %v", *unban, err)
+		}
+		log.Printf("unbanned %s", *unban)
+	}
+
+	if *listBans {
+		bans, err := database.ListConnectionBans()
+		if err != nil {
+			log.Fatalf("failed to list connection bans: %v", err)
+		}
+		for _, ban := range bans {
+			log.Printf("%s\t%s\t%s", ban.Kind, ban.Value, ban.Reason)
+		}
+	}
+
+	if *auditLogUsername != "" {
+		attempts, err := database.GetAuthAttempts(*auditLogUsername, *auditLogLimit)
+		if err != nil {
+			log.Fatalf("failed to get auth attempts for %s: %v", *auditLogUsername, err)
+		}
+		for _, attempt := range attempts {
+			log.Printf("%s\tsuccess=%t\t%s\t%s\t%s", attempt.CreatedAt, attempt.Success, attempt.Fingerprint, attempt.SourceIP, attempt.Reason)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Command ssh-server runs the SSH gateway players connect to to actually
+// play Term Idle.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/ssh"
+)
+
+func main() {
+	dbPath := flag.String("db", "term-idle.db", "path to the SQLite database")
+	addr := flag.String("addr", ":2222", "address for the SSH gateway to listen on")
+	hostKeyFile := flag.String("host-key", "term-idle.host_key", "path to the server's persistent Ed25519 host key")
+	motd := flag.String("motd", "", "message-of-the-day shown to every client before the game starts")
+	motdFile := flag.String("motd-file", "", "path to a message-of-the-day file, read fresh on every connection; overrides -motd")
+	banListFile := flag.String("ban-list", "", "path to a ban list file loaded on startup, merged with bans added through the admin API")
+	logDir := flag.String("log-dir", "", "directory for structured session logs; logs to stdout if unset")
+	maxSessions := flag.Int("max-sessions", 0, "max interactive sessions running at once; 0 means unlimited")
+	maxQueueSize := flag.Int("max-queue-size", 0, "connections beyond -max-sessions held waiting before new ones are turned away")
+	adminFingerprints := flag.String("admin-fingerprints", "", "comma-separated SSH key fingerprints allowed to run admin-only exec commands")
+	flag.Parse()
+
+	database, err := db.NewSQLiteDB(*dbPath, db.DefaultOptions())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close()
+
+	cfg := ssh.Config{
+		HostKeyFile: *hostKeyFile,
+		MOTD:        *motd,
+		MOTDFile:    *motdFile,
+		BanListFile: *banListFile,
+		LogDir:      *logDir,
+		RateLimit:   ssh.DefaultRateLimitConfig(),
+		Capacity:    ssh.CapacityConfig{MaxSessions: *maxSessions, MaxQueueSize: *maxQueueSize},
+	}
+	if *adminFingerprints != "" {
+		cfg.AdminFingerprints = strings.Split(*adminFingerprints, ",")
+	}
+
+	server, err := ssh.NewServer(database, nil, cfg, *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("ssh-server: listening on %s", *addr)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			log.Fatal(err)
+		}
+	case <-ctx.Done():
+		log.Println("ssh-server: shutting down")
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Printf("ssh-server: error during shutdown: %v", err)
+		}
+		<-errCh
+	}
+}
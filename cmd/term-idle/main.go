@@ -0,0 +1,85 @@
+// Command term-idle runs the Term Idle game server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/api"
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/webhook"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	dbPath := flag.String("db", "term-idle.db", "path to the SQLite database")
+	webhookURLs := flag.String("webhook-urls", "", "comma-separated URLs to POST milestone events to; leave unset to disable webhooks")
+	webhookSecret := flag.String("webhook-secret", "", "shared secret used to HMAC-sign outgoing webhook payloads")
+	readTimeout := flag.Duration("read-timeout", api.DefaultServerConfig().ReadTimeout, "max duration for reading a request, including its body")
+	writeTimeout := flag.Duration("write-timeout", api.DefaultServerConfig().WriteTimeout, "max duration for writing a response")
+	idleTimeout := flag.Duration("idle-timeout", api.DefaultServerConfig().IdleTimeout, "max duration a keep-alive connection may sit idle between requests")
+	sshAddr := flag.String("ssh-addr", "", "address of the SSH gateway (run separately via cmd/ssh-server) to include in health checks; leave unset if this deployment doesn't run one")
+	flag.Parse()
+
+	var database db.Database
+	sdb, err := db.NewSQLiteDB(*dbPath, db.DefaultOptions())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer sdb.Close()
+	database = sdb
+
+	if *webhookURLs != "" {
+		notifier := webhook.NewNotifier(webhook.Config{
+			URLs:   strings.Split(*webhookURLs, ","),
+			Secret: *webhookSecret,
+		})
+		database = db.NewNotifyingDB(database, notifier)
+	}
+	database = db.NewInstrumentedDB(database)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := api.DefaultServerConfig()
+	cfg.ReadTimeout = *readTimeout
+	cfg.WriteTimeout = *writeTimeout
+	cfg.IdleTimeout = *idleTimeout
+	server := api.NewServerWithConfig(database, cfg)
+	if *sshAddr != "" {
+		server.SetSSHAddr(*sshAddr)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Println("term-idle: listening on :8080")
+		if err := server.Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			log.Fatal(err)
+		}
+	case <-ctx.Done():
+		log.Println("term-idle: shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("term-idle: error during shutdown: %v", err)
+		}
+		<-errCh
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AbuseProtectionConfig bounds how much a single client IP can do to this
+// server at once, independently of the token-bucket rate limits in
+// ratelimit.go: those slow down a sustained attacker, while this catches
+// oversized bodies, a burst of concurrent connections, and a client that
+// keeps getting 4xx responses back.
+type AbuseProtectionConfig struct {
+	// MaxBodyBytes caps the size of a request body. Requests over the
+	// limit fail while being read, which handlers already treat as an
+	// invalid body. Zero disables the cap.
+	MaxBodyBytes int64
+	// MaxConcurrentPerIP caps how many requests from one IP may be
+	// in flight at once.
+	MaxConcurrentPerIP int
+	// BanThreshold is how many 4xx responses an IP may receive within
+	// BanWindow before it's temporarily banned.
+	BanThreshold int
+	// BanWindow is the sliding window BanThreshold is counted over.
+	BanWindow time.Duration
+	// BanDuration is how long an IP stays banned once it crosses
+	// BanThreshold.
+	BanDuration time.Duration
+}
+
+// DefaultAbuseProtectionConfig returns limits generous enough for a
+// well-behaved client but tight enough to blunt a single misbehaving one.
+func DefaultAbuseProtectionConfig() AbuseProtectionConfig {
+	return AbuseProtectionConfig{
+		MaxBodyBytes:       1 << 20,
+		MaxConcurrentPerIP: 10,
+		BanThreshold:       20,
+		BanWindow:          time.Minute,
+		BanDuration:        5 * time.Minute,
+	}
+}
+
+// abuseGuard tracks per-IP concurrency and 4xx history to back
+// abuseMiddleware. Its maps grow for the life of the process, the same
+// trade-off rateLimiter already makes for its per-key token buckets.
+type abuseGuard struct {
+	cfg AbuseProtectionConfig
+
+	mu          sync.Mutex
+	inFlight    map[string]int
+	violations  map[string][]time.Time
+	bannedUntil map[string]time.Time
+}
+
+func newAbuseGuard(cfg AbuseProtectionConfig) *abuseGuard {
+	return &abuseGuard{
+		cfg:         cfg,
+		inFlight:    make(map[string]int),
+		violations:  make(map[string][]time.Time),
+		bannedUntil: make(map[string]time.Time),
+	}
+}
+
+// bannedFor reports how much longer ip remains banned, or 0 if it isn't.
+func (g *abuseGuard) bannedFor(ip string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	until, ok := g.bannedUntil[ip]
+	if !ok {
+		return 0
+	}
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		delete(g.bannedUntil, ip)
+		return 0
+	}
+	return remaining
+}
+
+// acquire reserves a concurrent request slot for ip, reporting false if
+// MaxConcurrentPerIP is already in use. Callers must call release exactly
+// once when the request finishes, but only if acquire returned true.
+func (g *abuseGuard) acquire(ip string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.inFlight[ip] >= g.cfg.MaxConcurrentPerIP {
+		return false
+	}
+	g.inFlight[ip]++
+	return true
+}
+
+func (g *abuseGuard) release(ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.inFlight[ip]--
+	if g.inFlight[ip] <= 0 {
+		delete(g.inFlight, ip)
+	}
+}
+
+// recordStatus notes a response status from ip, banning it for BanDuration
+// once it crosses BanThreshold 4xx responses within BanWindow.
+func (g *abuseGuard) recordStatus(ip string, status int) {
+	if status < 400 || status >= 500 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-g.cfg.BanWindow)
+	kept := g.violations[ip][:0]
+	for _, t := range g.violations[ip] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	kept = append(kept, now)
+	g.violations[ip] = kept
+
+	if len(kept) >= g.cfg.BanThreshold {
+		g.bannedUntil[ip] = now.Add(g.cfg.BanDuration)
+		delete(g.violations, ip)
+	}
+}
+
+// abuseMiddleware enforces s.cfg.AbuseProtection: it rejects requests from
+// banned IPs, caps concurrent requests per IP, caps request body size, and
+// feeds every response's status back into the ban decision.
+func (s *Server) abuseMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := clientIP(r)
+
+		if remaining := s.abuseGuard.bannedFor(ip); remaining > 0 {
+			tooManyRequests(w, r, remaining)
+			return
+		}
+
+		if !s.abuseGuard.acquire(ip) {
+			writeError(w, r, http.StatusTooManyRequests, codeRateLimited, "too many concurrent requests from this address")
+			return
+		}
+		defer s.abuseGuard.release(ip)
+
+		if s.cfg.AbuseProtection.MaxBodyBytes > 0 && r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, s.cfg.AbuseProtection.MaxBodyBytes)
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.abuseGuard.recordStatus(ip, rec.status)
+	})
+}
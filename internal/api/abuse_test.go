@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAbuseMiddlewareRejectsOversizedBody(t *testing.T) {
+	s := newTestServer(t)
+	s.abuseGuard = newAbuseGuard(AbuseProtectionConfig{MaxBodyBytes: 8, MaxConcurrentPerIP: 10, BanThreshold: 100, BanWindow: time.Minute, BanDuration: time.Minute})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"username": "way too long for the limit"}`))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d, body = %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestAbuseMiddlewareRejectsTooManyConcurrentRequests(t *testing.T) {
+	s := newTestServer(t)
+	s.abuseGuard = newAbuseGuard(AbuseProtectionConfig{MaxBodyBytes: 1 << 20, MaxConcurrentPerIP: 1, BanThreshold: 100, BanWindow: time.Minute, BanDuration: time.Minute})
+
+	if !s.abuseGuard.acquire("192.0.2.1") {
+		t.Fatal("first acquire should succeed")
+	}
+	defer s.abuseGuard.release("192.0.2.1")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestAbuseMiddlewareBansAfterRepeated4xx(t *testing.T) {
+	s := newTestServer(t)
+	s.abuseGuard = newAbuseGuard(AbuseProtectionConfig{MaxBodyBytes: 1 << 20, MaxConcurrentPerIP: 10, BanThreshold: 3, BanWindow: time.Minute, BanDuration: time.Minute})
+
+	doMissingPlayer := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := doMissingPlayer(); code != http.StatusNotFound {
+			t.Fatalf("request %d status = %d, want %d", i, code, http.StatusNotFound)
+		}
+	}
+
+	if code := doMissingPlayer(); code != http.StatusTooManyRequests {
+		t.Errorf("status after crossing ban threshold = %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
+
+func TestAbuseMiddlewareDisabledSkipsAllLimits(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.EnableAbuseProtection = false
+	s := newTestServerWithConfig(t, cfg)
+	s.abuseGuard = newAbuseGuard(AbuseProtectionConfig{MaxBodyBytes: 1, MaxConcurrentPerIP: 1, BanThreshold: 1, BanWindow: time.Minute, BanDuration: time.Minute})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
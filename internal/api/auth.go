@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+type contextKey string
+
+const playerContextKey contextKey = "player"
+
+// requireAuth authenticates the bearer token in the Authorization header,
+// accepting either a long-lived API token or a short-lived JWT issued by
+// /api/auth/login, and rejects the request if it's missing or invalid. The
+// authenticated player is attached to the request context for handlers
+// that need to check resource ownership.
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.cfg.EnableAuth {
+			next(w, r)
+			return
+		}
+
+		token, ok := bearerToken(r)
+		if !ok {
+			writeError(w, r, http.StatusUnauthorized, codeMissingToken, "missing bearer token")
+			return
+		}
+
+		player, err := s.authenticate(token)
+		if errors.Is(err, db.ErrNotFound) {
+			writeError(w, r, http.StatusUnauthorized, codeInvalidToken, "invalid bearer token")
+			return
+		}
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to authenticate request")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), playerContextKey, player)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// authenticate resolves a bearer token to a player, trying it as a JWT
+// before falling back to an API token lookup.
+func (s *Server) authenticate(token string) (*db.Player, error) {
+	if playerID, err := s.parseJWT(token); err == nil {
+		return s.db.GetPlayer(playerID)
+	}
+	return s.db.AuthenticateAPIToken(token)
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// authenticatedPlayer returns the player requireAuth attached to the
+// request context, or nil if the route isn't behind requireAuth.
+func authenticatedPlayer(r *http.Request) *db.Player {
+	player, _ := r.Context().Value(playerContextKey).(*db.Player)
+	return player
+}
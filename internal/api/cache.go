@@ -0,0 +1,55 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// queryCache memoizes the result of an expensive read (e.g. a leaderboard
+// page or a global-stats aggregate) for ttl, keyed by whatever the caller
+// chooses to distinguish one query from another. A write path that
+// invalidates what it changed should call invalidate rather than wait out
+// the ttl, so readers never see the cache as more than briefly stale.
+type queryCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]queryCacheEntry
+}
+
+type queryCacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+func newQueryCache(ttl time.Duration) *queryCache {
+	return &queryCache{ttl: ttl, entries: make(map[string]queryCacheEntry)}
+}
+
+// get returns the cached value for key, if present and not yet expired.
+func (c *queryCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *queryCache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = queryCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// invalidate drops every cached entry, for a write path whose effect on a
+// cached query isn't worth expressing as a targeted key removal.
+func (c *queryCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]queryCacheEntry)
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryCacheReturnsValueUntilTTLExpires(t *testing.T) {
+	c := newQueryCache(10 * time.Millisecond)
+
+	if _, ok := c.get("k"); ok {
+		t.Fatal("get() on an empty cache should miss")
+	}
+
+	c.set("k", 42)
+	value, ok := c.get("k")
+	if !ok || value.(int) != 42 {
+		t.Fatalf("get() = (%v, %v), want (42, true)", value, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.get("k"); ok {
+		t.Error("get() should miss once the TTL has elapsed")
+	}
+}
+
+func TestQueryCacheInvalidateClearsEverything(t *testing.T) {
+	c := newQueryCache(time.Minute)
+	c.set("a", 1)
+	c.set("b", 2)
+
+	c.invalidate()
+
+	if _, ok := c.get("a"); ok {
+		t.Error("get(\"a\") should miss after invalidate()")
+	}
+	if _, ok := c.get("b"); ok {
+		t.Error("get(\"b\") should miss after invalidate()")
+	}
+}
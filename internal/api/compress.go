@@ -0,0 +1,48 @@
+package api
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// gzipResponseWriter transparently gzips everything written to it.
+// WriteHeader is inherited from the embedded ResponseWriter, so status
+// codes set by handlers pass through untouched.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (gw *gzipResponseWriter) Write(b []byte) (int, error) {
+	return gw.gz.Write(b)
+}
+
+// gzipMiddleware compresses responses for clients that advertise gzip
+// support via Accept-Encoding, which matters most for large JSON payloads
+// like a leaderboard page with hundreds of entries. Clients that don't ask
+// for it get an uncompressed response, so this is opt-in rather than
+// forced.
+func (s *Server) gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		next.ServeHTTP(&gzipResponseWriter{ResponseWriter: w, gz: gz}, r)
+	})
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(enc) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package api
+
+import "time"
+
+// ServerConfig controls which parts of the middleware chain NewServerWithConfig
+// builds, so an embedder can enable just what it needs instead of taking the
+// whole stack (e.g. an operator who already terminates CORS and auth at a
+// gateway in front of this service).
+type ServerConfig struct {
+	// EnableCORS adds the Access-Control-* headers needed for this API to be
+	// called directly from a browser-based dashboard.
+	EnableCORS bool
+	// CORSAllowedOrigins lists the origins echoed back in
+	// Access-Control-Allow-Origin when EnableCORS is set. An empty list
+	// allows any origin ("*"), matching an API with no browser session
+	// state to protect.
+	CORSAllowedOrigins []string
+	// EnableAuth requires a valid bearer token on routes that would
+	// otherwise need one. Disabling it is only safe behind a trusted
+	// embedder that performs its own authentication before requests reach
+	// this server.
+	EnableAuth bool
+	// EnableRateLimit throttles requests per client IP and per
+	// authenticated player.
+	EnableRateLimit bool
+	// EnableCompression gzip-encodes responses for clients that accept it.
+	EnableCompression bool
+	// EnableAbuseProtection caps request body size and per-IP concurrency,
+	// and temporarily bans IPs that rack up too many 4xx responses.
+	EnableAbuseProtection bool
+	// AbuseProtection configures EnableAbuseProtection's limits.
+	AbuseProtection AbuseProtectionConfig
+
+	// ReadTimeout caps how long Start waits to read a request, including
+	// its body.
+	ReadTimeout time.Duration
+	// WriteTimeout caps how long Start waits to write a response, measured
+	// from the end of the request headers.
+	WriteTimeout time.Duration
+	// IdleTimeout caps how long Start keeps a keep-alive connection open
+	// between requests.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes caps the size of request headers Start will read, so a
+	// client can't exhaust memory with an oversized header block.
+	MaxHeaderBytes int
+}
+
+// DefaultServerConfig returns the settings NewServer uses: every protection
+// enabled, CORS open to any origin, and timeouts generous enough for a
+// slow mobile client but short enough to stop a slowloris-style client
+// from holding a connection open indefinitely.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		EnableCORS:        true,
+		EnableAuth:        true,
+		EnableRateLimit:   true,
+		EnableCompression: true,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+
+		EnableAbuseProtection: true,
+		AbuseProtection:       DefaultAbuseProtectionConfig(),
+	}
+}
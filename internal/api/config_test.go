@@ -0,0 +1,115 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func newTestServerWithConfig(t *testing.T, cfg ServerConfig) *Server {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	sdb, err := db.NewSQLiteDB(path, db.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+
+	return NewServerWithConfig(sdb, cfg)
+}
+
+func TestServerWithAuthDisabledServesProtectedRoutesWithoutAToken(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.EnableAuth = false
+	s := newTestServerWithConfig(t, cfg)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1"}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/p1/state", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+}
+
+func TestServerWithCORSDisabledOmitsHeaders(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.EnableCORS = false
+	s := newTestServerWithConfig(t, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.Header.Set("Origin", "https://dashboard.example")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty", got)
+	}
+}
+
+func TestServerWithCORSEnabledEchoesAllowedOrigin(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.CORSAllowedOrigins = []string{"https://dashboard.example"}
+	s := newTestServerWithConfig(t, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.Header.Set("Origin", "https://dashboard.example")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://dashboard.example" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, "https://dashboard.example")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty for a disallowed origin", got)
+	}
+}
+
+func TestServerWithRateLimitDisabledNeverThrottles(t *testing.T) {
+	cfg := DefaultServerConfig()
+	cfg.EnableRateLimit = false
+	cfg.EnableAbuseProtection = false
+	s := newTestServerWithConfig(t, cfg)
+
+	for i := 0; i < 50; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		if rec.Code == http.StatusTooManyRequests {
+			t.Fatalf("request %d was rate limited despite EnableRateLimit = false", i)
+		}
+	}
+}
+
+func TestServerRouterMountsInsideAnotherMux(t *testing.T) {
+	s := newTestServer(t)
+
+	host := http.NewServeMux()
+	host.Handle("/", s.Router())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	rec := httptest.NewRecorder()
+	host.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+)
+
+// corsMiddleware adds the Access-Control-* headers needed for this API to
+// be called directly from a browser-based dashboard, and short-circuits
+// the preflight OPTIONS request browsers send ahead of one.
+func (s *Server) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && s.allowsOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		}
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowsOrigin reports whether origin may receive CORS headers: any origin,
+// if the config didn't name specific ones, or only those it named.
+func (s *Server) allowsOrigin(origin string) bool {
+	if len(s.cfg.CORSAllowedOrigins) == 0 {
+		return true
+	}
+	return slices.Contains(s.cfg.CORSAllowedOrigins, origin)
+}
@@ -0,0 +1,42 @@
+package api
+
+import "net/http"
+
+// errorCode is a stable, machine-readable identifier for an API error,
+// distinct from the human-readable message, so clients can branch on
+// error type without parsing prose.
+type errorCode string
+
+const (
+	codeValidationFailed    errorCode = "VALIDATION_FAILED"
+	codeInvalidBody         errorCode = "INVALID_BODY"
+	codeMissingToken        errorCode = "MISSING_TOKEN"
+	codeInvalidToken        errorCode = "INVALID_TOKEN"
+	codeForbidden           errorCode = "FORBIDDEN"
+	codePlayerNotFound      errorCode = "PLAYER_NOT_FOUND"
+	codeGameStateNotFound   errorCode = "GAME_STATE_NOT_FOUND"
+	codeLeaderboardNotFound errorCode = "LEADERBOARD_ENTRY_NOT_FOUND"
+	codeUsernameTaken       errorCode = "USERNAME_TAKEN"
+	codePairingCodeNotFound errorCode = "PAIRING_CODE_NOT_FOUND"
+	codePairingCodeClaimed  errorCode = "PAIRING_CODE_ALREADY_CLAIMED"
+	codeRateLimited         errorCode = "RATE_LIMITED"
+	codeInternal            errorCode = "INTERNAL_ERROR"
+)
+
+// errorResponse is the JSON body written for every non-2xx response, so
+// clients can rely on a single shape regardless of which handler failed.
+type errorResponse struct {
+	Error     string            `json:"error"`
+	Code      errorCode         `json:"code"`
+	RequestID string            `json:"request_id"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// writeError writes the standard error envelope.
+func writeError(w http.ResponseWriter, r *http.Request, status int, code errorCode, message string) {
+	writeJSON(w, status, errorResponse{
+		Error:     message,
+		Code:      code,
+		RequestID: requestID(r),
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorEnvelopeIncludesCodeAndRequestID(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Code != codePlayerNotFound {
+		t.Errorf("Code = %q, want %q", resp.Code, codePlayerNotFound)
+	}
+	if resp.RequestID == "" {
+		t.Error("RequestID is empty")
+	}
+	if resp.Error == "" {
+		t.Error("Error is empty")
+	}
+}
+
+func TestErrorEnvelopeIncludesFieldDetailsForValidationErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard?limit=-1", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Code != codeValidationFailed {
+		t.Errorf("Code = %q, want %q", resp.Code, codeValidationFailed)
+	}
+	if _, ok := resp.Details["limit"]; !ok {
+		t.Errorf("Details = %+v, want a \"limit\" entry", resp.Details)
+	}
+}
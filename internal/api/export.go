@@ -0,0 +1,195 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// errInvalidExportSignature is returned by verifyExport when a submitted
+// payload's signature doesn't match what this server would have produced.
+var errInvalidExportSignature = errors.New("export signature does not match payload")
+
+// exportPayload is everything needed to restore a player's progress
+// independently of this operator's database.
+type exportPayload struct {
+	Player        playerMetadata    `json:"player"`
+	GameState     *db.GameState     `json:"game_state"`
+	LifetimeStats *db.LifetimeStats `json:"lifetime_stats"`
+}
+
+// exportResponse wraps a payload with an HMAC signature over its exact
+// JSON encoding, so a player (or a future import endpoint) can detect the
+// file was altered after it was downloaded.
+type exportResponse struct {
+	Payload   json.RawMessage `json:"payload"`
+	Signature string          `json:"signature"`
+}
+
+// handleGetPlayerExport returns a player's full save as a signed JSON
+// attachment, so they can back it up independently of the operator. The
+// realm query parameter selects which GameState to export; it defaults to
+// "main" like every other per-realm endpoint.
+func (s *Server) handleGetPlayerExport(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+	realm := r.URL.Query().Get("realm")
+
+	player, err := s.db.GetPlayer(id)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codePlayerNotFound, "player not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to look up player")
+		return
+	}
+
+	state, err := s.db.GetGameState(id, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codeGameStateNotFound, "game state not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load game state")
+		return
+	}
+
+	lifetimeStats, err := s.db.GetLifetimeStats(id)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load lifetime stats")
+		return
+	}
+
+	payload, err := json.Marshal(exportPayload{
+		Player: playerMetadata{
+			ID:        player.ID,
+			Username:  player.Username,
+			Realm:     player.Realm,
+			CreatedAt: player.CreatedAt,
+		},
+		GameState:     state,
+		LifetimeStats: lifetimeStats,
+	})
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to encode export")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-save.json"`, player.ID))
+	writeJSON(w, http.StatusOK, exportResponse{
+		Payload:   payload,
+		Signature: s.signExport(payload),
+	})
+}
+
+// signExport returns the hex-encoded HMAC-SHA256 of payload keyed by the
+// server's JWT signing secret. Like issued JWTs, a signature only
+// validates for the lifetime of the process that produced it.
+func (s *Server) signExport(payload []byte) string {
+	mac := hmac.New(sha256.New, s.jwtSecret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyExport checks resp.Signature against resp.Payload, returning
+// errInvalidExportSignature if they don't match.
+func (s *Server) verifyExport(resp exportResponse) error {
+	want, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return errInvalidExportSignature
+	}
+	mac := hmac.New(sha256.New, s.jwtSecret)
+	mac.Write(resp.Payload)
+	if !hmac.Equal(mac.Sum(nil), want) {
+		return errInvalidExportSignature
+	}
+	return nil
+}
+
+// handlePostPlayerImport restores a save produced by handleGetPlayerExport,
+// for migrating a player's progress onto a different operator's server.
+// Because the signature is keyed by this process's JWT secret (see
+// signExport), an import only succeeds against the same server instance
+// that produced the export, or one that shares its secret.
+func (s *Server) handlePostPlayerImport(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+
+	var resp exportResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+
+	if err := s.verifyExport(resp); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, err.Error())
+		return
+	}
+
+	var payload exportPayload
+	if err := json.Unmarshal(resp.Payload, &payload); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid export payload")
+		return
+	}
+	if payload.Player.ID != id {
+		writeValidationError(w, r, "payload", "export belongs to a different player")
+		return
+	}
+	if payload.GameState == nil {
+		writeValidationError(w, r, "payload", "export is missing game_state")
+		return
+	}
+	payload.GameState.PlayerID = id
+
+	if errs := validateGameState(payload.GameState); !errs.ok() {
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	if err := s.db.SaveGameState(payload.GameState); err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to save game state")
+		return
+	}
+
+	if payload.LifetimeStats != nil {
+		if err := s.restoreLifetimeStats(id, payload.LifetimeStats); err != nil {
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to save lifetime stats")
+			return
+		}
+		s.globalStatsCache.invalidate()
+	}
+
+	writeJSON(w, http.StatusOK, payload.GameState)
+}
+
+// restoreLifetimeStats brings a player's lifetime stats up to imported,
+// computing the delta against their current totals since AddLifetimeStats
+// only increments. This makes a repeated import of the same export a
+// no-op rather than double-counting.
+func (s *Server) restoreLifetimeStats(playerID string, imported *db.LifetimeStats) error {
+	current, err := s.db.GetLifetimeStats(playerID)
+	if err != nil {
+		return fmt.Errorf("failed to load current lifetime stats: %w", err)
+	}
+
+	return s.db.AddLifetimeStats(playerID, db.LifetimeStats{
+		TotalKeystrokes: imported.TotalKeystrokes - current.TotalKeystrokes,
+		TotalWords:      imported.TotalWords - current.TotalWords,
+		TotalPrograms:   imported.TotalPrograms - current.TotalPrograms,
+		PlaytimeSeconds: imported.PlaytimeSeconds - current.PlaytimeSeconds,
+		SessionsPlayed:  imported.SessionsPlayed - current.SessionsPlayed,
+	})
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// maxGameLevel is the highest level a game state can report; it matches
+// the top entry in db.LevelMilestones, the last tier the game has content
+// for.
+const maxGameLevel = 100
+
+func (s *Server) handleGetGameState(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+	realm := r.URL.Query().Get("realm")
+
+	state, err := s.db.GetGameState(id, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codeGameStateNotFound, "game state not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load game state")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *Server) handlePutGameState(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+
+	var state db.GameState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+	state.PlayerID = id
+
+	if errs := validateGameState(&state); !errs.ok() {
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	if err := s.db.SaveGameState(&state); err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to save game state")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+// validateGameState checks every field of state and returns every
+// violation found, rather than stopping at the first, so a client can fix
+// a rejected submission in one round trip.
+func validateGameState(state *db.GameState) fieldErrors {
+	errs := fieldErrors{}
+	if state.PlayerID == "" {
+		errs.add("player_id", "player_id is required")
+	}
+	if state.CurrentLevel < 0 {
+		errs.add("current_level", "current_level must not be negative")
+	} else if state.CurrentLevel > maxGameLevel {
+		errs.add("current_level", fmt.Sprintf("current_level must not exceed %d", maxGameLevel))
+	}
+	if state.Keystrokes < 0 {
+		errs.add("keystrokes", "keystrokes must not be negative")
+	}
+	if state.Words < 0 {
+		errs.add("words", "words must not be negative")
+	}
+	if state.Programs < 0 {
+		errs.add("programs", "programs must not be negative")
+	}
+	if state.AIAutomations < 0 {
+		errs.add("ai_automations", "ai_automations must not be negative")
+	}
+	if state.StoryProgress < 0 {
+		errs.add("story_progress", "story_progress must not be negative")
+	}
+	if state.ProductionRate < 0 {
+		errs.add("production_rate", "production_rate must not be negative")
+	}
+	return errs
+}
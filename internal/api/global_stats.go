@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+const globalStatsCacheKey = "global"
+
+// handleGetGlobalStats returns server-wide totals across every player, for
+// a "here's how much progress everyone has made together" display. The
+// aggregate is expensive enough, and changes little enough request to
+// request, that it's worth serving from s.globalStatsCache rather than
+// scanning lifetime_stats on every poll.
+func (s *Server) handleGetGlobalStats(w http.ResponseWriter, r *http.Request) {
+	cached, found := s.globalStatsCache.get(globalStatsCacheKey)
+	stats, ok := cached.(*db.GlobalStats)
+	if !found || !ok {
+		var err error
+		stats, err = s.db.GetGlobalStats()
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load global stats")
+			return
+		}
+		s.globalStatsCache.set(globalStatsCacheKey, stats)
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
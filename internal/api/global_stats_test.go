@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleGetGlobalStatsAggregatesAcrossPlayers(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	if err := s.db.AddLifetimeStats("p1", db.LifetimeStats{TotalKeystrokes: 10}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+	if err := s.db.AddLifetimeStats("p2", db.LifetimeStats{TotalKeystrokes: 5}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var stats db.GlobalStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if stats.TotalPlayers != 2 {
+		t.Errorf("TotalPlayers = %d, want 2", stats.TotalPlayers)
+	}
+	if stats.TotalKeystrokes != 15 {
+		t.Errorf("TotalKeystrokes = %v, want 15", stats.TotalKeystrokes)
+	}
+}
+
+func TestHandleGetGlobalStatsServesCachedValueWithinTTL(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	doGet := func() db.GlobalStats {
+		req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		var stats db.GlobalStats
+		if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return stats
+	}
+
+	if stats := doGet(); stats.TotalPlayers != 1 {
+		t.Fatalf("TotalPlayers = %d, want 1", stats.TotalPlayers)
+	}
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p2", Username: "p2"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if stats := doGet(); stats.TotalPlayers != 1 {
+		t.Errorf("TotalPlayers = %d, want the cached value of 1", stats.TotalPlayers)
+	}
+}
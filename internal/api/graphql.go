@@ -0,0 +1,511 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// graphqlRequest is the standard GraphQL-over-HTTP request body: a query
+// document plus any variables it references by name.
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphqlResponse is the standard GraphQL-over-HTTP response shape. Unlike
+// the rest of the API, a GraphQL error is reported inside a 200 response
+// alongside whatever data was resolved, per the GraphQL spec.
+type graphqlResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []graphqlError `json:"errors,omitempty"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+// handleGraphQL answers a single read-only query document against players,
+// leaderboard entries, game state, and achievements, letting a dashboard
+// fetch exactly the fields it needs in one round trip instead of stitching
+// together several REST calls.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+
+	selections, err := parseGraphQLQuery(req.Query)
+	if err != nil {
+		writeJSON(w, http.StatusOK, graphqlResponse{Errors: []graphqlError{{Message: err.Error()}}})
+		return
+	}
+
+	data := map[string]any{}
+	var errs []graphqlError
+	for _, sel := range selections {
+		value, err := s.resolveGraphQLField(sel, req.Variables)
+		if err != nil {
+			errs = append(errs, graphqlError{Message: err.Error()})
+			continue
+		}
+		data[sel.alias()] = value
+	}
+
+	writeJSON(w, http.StatusOK, graphqlResponse{Data: data, Errors: errs})
+}
+
+// resolveGraphQLField dispatches a single top-level selection to the
+// resolver for the query field it names.
+func (s *Server) resolveGraphQLField(sel graphqlSelection, vars map[string]any) (any, error) {
+	args, err := sel.resolveArgs(vars)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", sel.name, err)
+	}
+
+	switch sel.name {
+	case "player":
+		return s.resolvePlayer(sel, args)
+	case "leaderboard":
+		return s.resolveLeaderboard(sel, args)
+	case "gameState":
+		return s.resolveGameState(sel, args)
+	case "achievements":
+		return s.resolveAchievements(sel, args)
+	default:
+		return nil, fmt.Errorf("unknown field %q", sel.name)
+	}
+}
+
+func (s *Server) resolvePlayer(sel graphqlSelection, args map[string]any) (any, error) {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return nil, errors.New("player: id argument is required")
+	}
+
+	player, err := s.db.GetPlayer(id)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("player: %w", err)
+	}
+
+	return sel.project(map[string]any{
+		"id":         player.ID,
+		"username":   player.Username,
+		"realm":      player.Realm,
+		"banned":     player.Banned,
+		"suspended":  player.Suspended,
+		"created_at": player.CreatedAt,
+	}), nil
+}
+
+func (s *Server) resolveLeaderboard(sel graphqlSelection, args map[string]any) (any, error) {
+	realm, _ := args["realm"].(string)
+
+	sort := db.SortByKeystrokes
+	if raw, ok := args["sort"].(string); ok && raw != "" {
+		parsed, ok := leaderboardSorts[raw]
+		if !ok {
+			return nil, fmt.Errorf("leaderboard: sort must be one of: keystrokes, words, programs")
+		}
+		sort = parsed
+	}
+
+	limit := defaultLeaderboardLimit
+	if v, ok := args["limit"]; ok {
+		n, err := graphqlInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("leaderboard: limit %w", err)
+		}
+		limit = min(n, maxLeaderboardLimit)
+	}
+
+	offset := 0
+	if v, ok := args["offset"]; ok {
+		n, err := graphqlInt(v)
+		if err != nil {
+			return nil, fmt.Errorf("leaderboard: offset %w", err)
+		}
+		offset = n
+	}
+
+	entries, err := s.db.GetLeaderboard(realm, sort, 0, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("leaderboard: %w", err)
+	}
+
+	entriesSel := sel.child("entries")
+	projected := make([]any, len(entries))
+	for i, e := range entries {
+		projected[i] = entriesSel.project(map[string]any{
+			"player_id":  e.PlayerID,
+			"realm":      e.Realm,
+			"username":   e.Username,
+			"keystrokes": e.Keystrokes,
+			"words":      e.Words,
+			"programs":   e.Programs,
+			"rank":       e.Rank,
+			"updated_at": e.UpdatedAt,
+		})
+	}
+
+	total, err := s.db.CountLeaderboard(realm, 0)
+	if err != nil {
+		return nil, fmt.Errorf("leaderboard: %w", err)
+	}
+
+	return sel.project(map[string]any{
+		"entries": projected,
+		"total":   total,
+	}), nil
+}
+
+func (s *Server) resolveGameState(sel graphqlSelection, args map[string]any) (any, error) {
+	playerID, _ := args["playerID"].(string)
+	if playerID == "" {
+		return nil, errors.New("gameState: playerID argument is required")
+	}
+	realm, _ := args["realm"].(string)
+
+	state, err := s.db.GetGameState(playerID, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gameState: %w", err)
+	}
+
+	return sel.project(map[string]any{
+		"player_id":       state.PlayerID,
+		"realm":           state.Realm,
+		"current_level":   state.CurrentLevel,
+		"keystrokes":      state.Keystrokes,
+		"words":           state.Words,
+		"programs":        state.Programs,
+		"ai_automations":  state.AIAutomations,
+		"story_progress":  state.StoryProgress,
+		"production_rate": state.ProductionRate,
+		"updated_at":      state.UpdatedAt,
+	}), nil
+}
+
+// resolveAchievements reports which of db.LevelMilestones a player has
+// reached, derived from their current game state rather than stored
+// separately, since the game doesn't persist achievements of its own yet.
+func (s *Server) resolveAchievements(sel graphqlSelection, args map[string]any) (any, error) {
+	playerID, _ := args["playerID"].(string)
+	if playerID == "" {
+		return nil, errors.New("achievements: playerID argument is required")
+	}
+	realm, _ := args["realm"].(string)
+
+	state, err := s.db.GetGameState(playerID, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		return []any{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("achievements: %w", err)
+	}
+
+	achievements := make([]any, 0, len(db.LevelMilestones))
+	for _, level := range db.LevelMilestones {
+		achievements = append(achievements, sel.project(map[string]any{
+			"level":   level,
+			"reached": state.CurrentLevel >= level,
+		}))
+	}
+	return achievements, nil
+}
+
+func graphqlInt(v any) (int, error) {
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case float64:
+		return int(n), nil
+	case string:
+		parsed, err := strconv.Atoi(n)
+		if err != nil {
+			return 0, fmt.Errorf("must be an integer: %w", err)
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("must be an integer")
+	}
+}
+
+// graphqlSelection is one field in a parsed query document, along with any
+// arguments it was called with and the sub-selections chosen for its
+// children, if it resolves to an object or list of objects.
+type graphqlSelection struct {
+	name         string
+	fieldAlias   string
+	args         map[string]graphqlValue
+	selectionSet map[string]graphqlSelection
+}
+
+func (s graphqlSelection) alias() string {
+	if s.fieldAlias != "" {
+		return s.fieldAlias
+	}
+	return s.name
+}
+
+// child looks up a named sub-selection, returning a selection with no
+// fields chosen (which project treats as "select everything") if the
+// caller didn't ask for it.
+func (s graphqlSelection) child(name string) graphqlSelection {
+	if sel, ok := s.selectionSet[name]; ok {
+		return sel
+	}
+	return graphqlSelection{name: name}
+}
+
+// project narrows fields down to exactly the keys the query selected,
+// recursing into any requested sub-selections. An empty selection set (the
+// resolver was invoked as a leaf object, e.g. in a test) returns fields
+// unfiltered.
+func (s graphqlSelection) project(fields map[string]any) map[string]any {
+	if len(s.selectionSet) == 0 {
+		return fields
+	}
+	out := make(map[string]any, len(s.selectionSet))
+	for name, childSel := range s.selectionSet {
+		out[childSel.alias()] = fields[name]
+	}
+	return out
+}
+
+func (s graphqlSelection) resolveArgs(vars map[string]any) (map[string]any, error) {
+	resolved := make(map[string]any, len(s.args))
+	for name, v := range s.args {
+		value, err := v.resolve(vars)
+		if err != nil {
+			return nil, err
+		}
+		resolved[name] = value
+	}
+	return resolved, nil
+}
+
+// graphqlValue is an argument value as written in the query: either a
+// literal or a reference to a request variable.
+type graphqlValue struct {
+	literal  any
+	variable string
+}
+
+func (v graphqlValue) resolve(vars map[string]any) (any, error) {
+	if v.variable == "" {
+		return v.literal, nil
+	}
+	value, ok := vars[v.variable]
+	if !ok {
+		return nil, fmt.Errorf("undefined variable $%s", v.variable)
+	}
+	return value, nil
+}
+
+// parseGraphQLQuery parses the minimal subset of GraphQL this endpoint
+// supports: a single anonymous query with field selections, arguments, and
+// nested selection sets. It deliberately doesn't support fragments,
+// mutations, directives, or multiple operations — callers needing those
+// should use the REST API instead.
+func parseGraphQLQuery(query string) ([]graphqlSelection, error) {
+	p := &graphqlParser{tokens: tokenizeGraphQL(query)}
+
+	if p.peek() == "query" {
+		p.next()
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected trailing input near %q", p.peek())
+	}
+	return selections, nil
+}
+
+type graphqlParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *graphqlParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *graphqlParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *graphqlParser) expect(tok string) error {
+	if p.peek() != tok {
+		return fmt.Errorf("expected %q, got %q", tok, p.peek())
+	}
+	p.next()
+	return nil
+}
+
+// parseSelectionSet parses fields up to the closing brace, which it
+// consumes.
+func (p *graphqlParser) parseSelectionSet() ([]graphqlSelection, error) {
+	var selections []graphqlSelection
+	for p.peek() != "}" {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+		if p.peek() == "" {
+			return nil, errors.New("unexpected end of query, expected \"}\"")
+		}
+	}
+	p.next()
+	return selections, nil
+}
+
+func (p *graphqlParser) parseSelection() (graphqlSelection, error) {
+	name := p.next()
+	if !isGraphQLName(name) {
+		return graphqlSelection{}, fmt.Errorf("expected a field name, got %q", name)
+	}
+	sel := graphqlSelection{name: name}
+
+	if p.peek() == ":" {
+		p.next()
+		sel.fieldAlias = name
+		sel.name = p.next()
+		if !isGraphQLName(sel.name) {
+			return graphqlSelection{}, fmt.Errorf("expected a field name, got %q", sel.name)
+		}
+	}
+
+	if p.peek() == "(" {
+		args, err := p.parseArguments()
+		if err != nil {
+			return graphqlSelection{}, err
+		}
+		sel.args = args
+	}
+
+	if p.peek() == "{" {
+		p.next()
+		children, err := p.parseSelectionSet()
+		if err != nil {
+			return graphqlSelection{}, err
+		}
+		sel.selectionSet = make(map[string]graphqlSelection, len(children))
+		for _, child := range children {
+			sel.selectionSet[child.name] = child
+		}
+	}
+
+	return sel, nil
+}
+
+func (p *graphqlParser) parseArguments() (map[string]graphqlValue, error) {
+	p.next() // consume "("
+	args := map[string]graphqlValue{}
+	for p.peek() != ")" {
+		name := p.next()
+		if !isGraphQLName(name) {
+			return nil, fmt.Errorf("expected an argument name, got %q", name)
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next() // consume ")"
+	return args, nil
+}
+
+func (p *graphqlParser) parseValue() (graphqlValue, error) {
+	tok := p.next()
+	switch {
+	case strings.HasPrefix(tok, "$"):
+		return graphqlValue{variable: strings.TrimPrefix(tok, "$")}, nil
+	case strings.HasPrefix(tok, `"`):
+		return graphqlValue{literal: strings.Trim(tok, `"`)}, nil
+	case tok == "true" || tok == "false":
+		return graphqlValue{literal: tok == "true"}, nil
+	default:
+		if n, err := strconv.Atoi(tok); err == nil {
+			return graphqlValue{literal: n}, nil
+		}
+		return graphqlValue{}, fmt.Errorf("unexpected value %q", tok)
+	}
+}
+
+func isGraphQLName(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for i, r := range tok {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		if i == 0 && !isLetter {
+			return false
+		}
+		if !isLetter && !isDigit {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizeGraphQL splits a query document into punctuation, quoted
+// strings, and bare words (names, numbers, booleans, $variables).
+func tokenizeGraphQL(query string) []string {
+	var tokens []string
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ',':
+			continue
+		case strings.ContainsRune("{}():", r):
+			tokens = append(tokens, string(r))
+		case r == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:min(i+1, len(runes))]))
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r,{}():\"", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+			i--
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func postGraphQL(t *testing.T, s *Server, query string, variables map[string]any) graphqlResponse {
+	t.Helper()
+
+	body, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp graphqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v, body = %s", err, rec.Body.String())
+	}
+	return resp
+}
+
+func TestHandleGraphQLResolvesPlayerWithFieldSelection(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	resp := postGraphQL(t, s, `{ player(id: "p1") { id username } }`, nil)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("Errors = %v, want none", resp.Errors)
+	}
+
+	data, ok := resp.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("Data = %v, want a map", resp.Data)
+	}
+	player, ok := data["player"].(map[string]any)
+	if !ok {
+		t.Fatalf("Data[\"player\"] = %v, want a map", data["player"])
+	}
+	if player["username"] != "monkey" {
+		t.Errorf("username = %v, want %q", player["username"], "monkey")
+	}
+	if _, ok := player["realm"]; ok {
+		t.Errorf("player unexpectedly includes unselected field %q: %v", "realm", player)
+	}
+}
+
+func TestHandleGraphQLResolvesLeaderboardAndAchievements(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", CurrentLevel: 30}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	if err := s.db.UpdateLeaderboard(&db.LeaderboardEntry{PlayerID: "p1", Username: "monkey", Keystrokes: 500}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+
+	resp := postGraphQL(t, s, `{
+		leaderboard(limit: 10) { total entries { username keystrokes } }
+		achievements(playerID: "p1") { level reached }
+	}`, nil)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("Errors = %v, want none", resp.Errors)
+	}
+
+	data := resp.Data.(map[string]any)
+	lb := data["leaderboard"].(map[string]any)
+	if lb["total"].(float64) != 1 {
+		t.Errorf("total = %v, want 1", lb["total"])
+	}
+	entries := lb["entries"].([]any)
+	if len(entries) != 1 || entries[0].(map[string]any)["username"] != "monkey" {
+		t.Errorf("entries = %v, want one entry for monkey", entries)
+	}
+
+	achievements := data["achievements"].([]any)
+	if len(achievements) != len(db.LevelMilestones) {
+		t.Fatalf("len(achievements) = %d, want %d", len(achievements), len(db.LevelMilestones))
+	}
+	reachedCount := 0
+	for _, a := range achievements {
+		if a.(map[string]any)["reached"] == true {
+			reachedCount++
+		}
+	}
+	if reachedCount != 2 { // milestones 10 and 25 are <= level 30
+		t.Errorf("reachedCount = %d, want 2", reachedCount)
+	}
+}
+
+func TestHandleGraphQLReportsErrorForUnknownField(t *testing.T) {
+	s := newTestServer(t)
+
+	resp := postGraphQL(t, s, `{ bogus { id } }`, nil)
+	if len(resp.Errors) == 0 {
+		t.Fatal("Errors is empty, want an error for the unknown field")
+	}
+}
+
+func TestHandleGraphQLReportsSyntaxError(t *testing.T) {
+	s := newTestServer(t)
+
+	resp := postGraphQL(t, s, `{ player(id: "p1") `, nil)
+	if len(resp.Errors) == 0 {
+		t.Fatal("Errors is empty, want a syntax error for the unterminated query")
+	}
+}
+
+func TestHandleGraphQLResolvesVariables(t *testing.T) {
+	s := newTestServer(t)
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	resp := postGraphQL(t, s, `query { player(id: $id) { username } }`, map[string]any{"id": "p1"})
+	if len(resp.Errors) != 0 {
+		t.Fatalf("Errors = %v, want none", resp.Errors)
+	}
+	data := resp.Data.(map[string]any)
+	player := data["player"].(map[string]any)
+	if player["username"] != "monkey" {
+		t.Errorf("username = %v, want %q", player["username"], "monkey")
+	}
+}
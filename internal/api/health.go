@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// healthCheckTimeout bounds how long the health check waits on the
+// database and SSH gateway before declaring them down, so a hung
+// dependency can't make the health check itself hang.
+const healthCheckTimeout = 2 * time.Second
+
+type healthResponse struct {
+	Status   string `json:"status"`
+	Version  string `json:"version"`
+	Uptime   string `json:"uptime"`
+	Database string `json:"database"`
+	SSH      string `json:"ssh,omitempty"`
+}
+
+// handleHealth reports the server's own uptime and build version alongside
+// the reachability of its dependencies, so load balancers and operators
+// can tell a genuinely unhealthy instance apart from one that's merely
+// idle.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	resp := healthResponse{
+		Status:  "ok",
+		Version: Version,
+		Uptime:  time.Since(s.startedAt).Round(time.Second).String(),
+	}
+
+	if err := s.db.Ping(ctx); err != nil {
+		resp.Status = "unavailable"
+		resp.Database = err.Error()
+	} else {
+		resp.Database = "ok"
+	}
+
+	if s.sshAddr != "" {
+		if err := checkSSHReachable(ctx, s.sshAddr); err != nil {
+			resp.Status = "unavailable"
+			resp.SSH = err.Error()
+		} else {
+			resp.SSH = "ok"
+		}
+	}
+
+	status := http.StatusOK
+	if resp.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+func checkSSHReachable(ctx context.Context, addr string) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+type statusResponse struct {
+	Status string `json:"status"`
+}
+
+// handleLiveness reports whether the process is alive at all, with no
+// dependency checks. Orchestrators use this to decide whether to restart
+// the container; a dependency outage (handled by handleReadiness instead)
+// isn't something a restart would fix.
+func (s *Server) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ok"})
+}
+
+// handleReadiness reports whether the server is ready to accept traffic,
+// i.e. its database is reachable. Orchestrators use this to decide whether
+// to route traffic to this instance, separately from whether to restart it.
+func (s *Server) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	if err := s.db.Ping(ctx); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, statusResponse{Status: "not ready"})
+		return
+	}
+
+	if s.sshAddr != "" {
+		if err := checkSSHReachable(ctx, s.sshAddr); err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, statusResponse{Status: "not ready"})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ready"})
+}
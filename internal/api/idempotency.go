@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// idempotencyHeader is the header a client sets to make a write endpoint
+// safe to retry: resending the exact same request with the same key
+// returns the first attempt's response instead of applying it again. It's
+// aimed at the SSH gateway's own retries against this API over a flaky
+// connection, not at browser clients.
+const idempotencyHeader = "Idempotency-Key"
+
+// idempotencyKeyTTL bounds how long a processed key is remembered. Long
+// enough to absorb a burst of retries in quick succession, short enough
+// that the store doesn't need to track anything beyond an expiry time.
+const idempotencyKeyTTL = 5 * time.Minute
+
+// idempotencyResponse is a full response captured by idempotencyStore, so
+// a retried request gets back exactly what the original attempt produced.
+type idempotencyResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+type idempotencyEntry struct {
+	response  idempotencyResponse
+	expiresAt time.Time
+}
+
+// idempotencyStore remembers the response to each idempotency key seen, so
+// idempotent can replay it rather than re-running the handler.
+type idempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]idempotencyEntry
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{entries: make(map[string]idempotencyEntry)}
+}
+
+func (s *idempotencyStore) get(key string) (idempotencyResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return idempotencyResponse{}, false
+	}
+	return entry.response, true
+}
+
+func (s *idempotencyStore) set(key string, resp idempotencyResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{response: resp, expiresAt: time.Now().Add(idempotencyKeyTTL)}
+}
+
+// bufferedResponseWriter captures a handler's response instead of sending
+// it, so idempotent can decide whether to keep it before it ever reaches
+// the real client.
+type bufferedResponseWriter struct {
+	header     http.Header
+	body       bytes.Buffer
+	statusCode int
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header)}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header { return w.header }
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.statusCode = status
+}
+
+// idempotent makes next safe to retry. A request without an
+// Idempotency-Key header passes straight through unchanged. One that
+// reuses a key already seen for this method and path gets back the
+// original response verbatim instead of being processed again. Only
+// successful and client-error responses are remembered; a 5xx is assumed
+// to not have taken effect, so a retry gets a real second attempt.
+func (s *Server) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		storeKey := r.Method + " " + r.URL.Path + "|" + key
+
+		if cached, ok := s.idempotencyStore.get(storeKey); ok {
+			writeBufferedResponse(w, cached.header, cached.status, cached.body)
+			return
+		}
+
+		buf := newBufferedResponseWriter()
+		next(buf, r)
+		if buf.statusCode == 0 {
+			buf.statusCode = http.StatusOK
+		}
+
+		if buf.statusCode < http.StatusInternalServerError {
+			s.idempotencyStore.set(storeKey, idempotencyResponse{
+				status: buf.statusCode,
+				header: buf.header,
+				body:   buf.body.Bytes(),
+			})
+		}
+
+		writeBufferedResponse(w, buf.header, buf.statusCode, buf.body.Bytes())
+	}
+}
+
+func writeBufferedResponse(w http.ResponseWriter, header http.Header, status int, body []byte) {
+	for name, values := range header {
+		for _, v := range values {
+			w.Header().Add(name, v)
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandlePutLeaderboardEntryRepeatedIdempotencyKeyDoesNotDoubleApply(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 1000}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	submit := func() *httptest.ResponseRecorder {
+		body := strings.NewReader(`{"keystrokes": 10}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(idempotencyHeader, "retry-1")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := submit()
+	if first.Code != http.StatusOK {
+		t.Fatalf("first status = %d, want %d, body = %s", first.Code, http.StatusOK, first.Body.String())
+	}
+
+	second := submit()
+	if second.Code != http.StatusOK {
+		t.Fatalf("second status = %d, want %d, body = %s", second.Code, http.StatusOK, second.Body.String())
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("second response body = %s, want identical to first %s", second.Body.String(), first.Body.String())
+	}
+}
+
+func TestHandlePutLeaderboardEntryDifferentIdempotencyKeysBothApply(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 1000}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	submit := func(key string) int {
+		body := strings.NewReader(`{"keystrokes": 10}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(idempotencyHeader, key)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := submit("key-a"); code != http.StatusOK {
+		t.Fatalf("first status = %d, want %d", code, http.StatusOK)
+	}
+	if code := submit("key-b"); code != http.StatusOK {
+		t.Fatalf("second status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestHandlePutLeaderboardEntryWithoutIdempotencyKeyAlwaysReapplies(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 1000}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	submit := func() int {
+		body := strings.NewReader(`{"keystrokes": 10}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := submit(); code != http.StatusOK {
+		t.Fatalf("first status = %d, want %d", code, http.StatusOK)
+	}
+	if code := submit(); code != http.StatusOK {
+		t.Fatalf("second status = %d, want %d", code, http.StatusOK)
+	}
+}
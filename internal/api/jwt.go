@@ -0,0 +1,64 @@
+package api
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtTTL is how long an issued access token remains valid. Short-lived by
+// design, since the API token exchanged for it never leaves the client.
+const jwtTTL = 15 * time.Minute
+
+type playerClaims struct {
+	PlayerID string `json:"player_id"`
+	jwt.RegisteredClaims
+}
+
+// newJWTSecret generates a random signing key for this process's lifetime.
+// Tokens issued before a restart stop validating afterward; clients are
+// expected to re-authenticate rather than hold a long-lived session.
+func newJWTSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate JWT signing secret: %w", err)
+	}
+	return secret, nil
+}
+
+func (s *Server) issueJWT(playerID string) (string, error) {
+	now := time.Now()
+	claims := playerClaims{
+		PlayerID: playerID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(jwtTTL)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.jwtSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT for %s: %w", playerID, err)
+	}
+	return token, nil
+}
+
+func (s *Server) parseJWT(token string) (string, error) {
+	var claims playerClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return s.jwtSecret, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if claims.PlayerID == "" {
+		return "", errors.New("JWT missing player_id claim")
+	}
+	return claims.PlayerID, nil
+}
@@ -0,0 +1,286 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+const (
+	defaultLeaderboardAroundRange = 5
+	maxLeaderboardAroundRange     = 50
+)
+
+// leaderboardSubmissionTolerance absorbs small timing gaps between when a
+// client's last GameState sync landed and when it submits a leaderboard
+// entry, without opening the door to meaningfully inflated numbers.
+const leaderboardSubmissionTolerance = 1.0
+
+// maxPlausibleKeystrokesPerSecond bounds how much a submission's
+// keystrokes may grow beyond the player's last saved GameState, per
+// second elapsed since that save. It's generous enough to cover real
+// typing plus idle production, while still catching a submission that
+// claims far more progress than the elapsed time allows.
+const maxPlausibleKeystrokesPerSecond = 50.0
+
+// leaderboardCacheMaxAge bounds how long a client may reuse a cached
+// leaderboard response before revalidating, balancing freshness against
+// load from clients polling the leaderboard.
+const leaderboardCacheMaxAge = 5 * time.Second
+
+// hotQueryCacheTTL bounds how long this server reuses its own in-process
+// result for an unchanged leaderboard or global-stats query, so a burst of
+// TUI clients polling the same page within the window shares one database
+// round trip. It's shorter than leaderboardCacheMaxAge, which governs how
+// long a specific client may skip asking at all.
+const hotQueryCacheTTL = 2 * time.Second
+
+type leaderboardQueryResult struct {
+	entries []*db.LeaderboardEntry
+	total   int
+}
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 200
+)
+
+// leaderboardSorts whitelists the sort values accepted on the query string,
+// mapping each to the db.LeaderboardSort it corresponds to.
+var leaderboardSorts = map[string]db.LeaderboardSort{
+	"keystrokes": db.SortByKeystrokes,
+	"words":      db.SortByWords,
+	"programs":   db.SortByPrograms,
+}
+
+type leaderboardResponse struct {
+	Entries    []*db.LeaderboardEntry `json:"entries"`
+	Total      int                    `json:"total"`
+	Offset     int                    `json:"offset"`
+	Limit      int                    `json:"limit"`
+	NextOffset *int                   `json:"next_offset,omitempty"`
+}
+
+func (s *Server) handleGetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	realm := query.Get("realm")
+
+	limit := defaultLeaderboardLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeValidationError(w, r, "limit", "limit must be a positive integer")
+			return
+		}
+		limit = min(parsed, maxLeaderboardLimit)
+	}
+
+	offset := 0
+	if raw := query.Get("offset"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			writeValidationError(w, r, "offset", "offset must not be negative")
+			return
+		}
+		offset = parsed
+	}
+
+	sort := db.SortByKeystrokes
+	if raw := query.Get("sort"); raw != "" {
+		parsed, ok := leaderboardSorts[raw]
+		if !ok {
+			writeValidationError(w, r, "sort", "sort must be one of: keystrokes, words, programs")
+			return
+		}
+		sort = parsed
+	}
+
+	minLevel := 0
+	if raw := query.Get("min_level"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			writeValidationError(w, r, "min_level", "min_level must not be negative")
+			return
+		}
+		minLevel = parsed
+	}
+
+	cacheKey := fmt.Sprintf("%s|%s|%d|%d|%d", realm, sort, minLevel, limit, offset)
+	cached, found := s.leaderboardCache.get(cacheKey)
+	result, ok := cached.(leaderboardQueryResult)
+	if !found || !ok {
+		entries, err := s.db.GetLeaderboard(realm, sort, minLevel, limit, offset)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load leaderboard")
+			return
+		}
+
+		total, err := s.db.CountLeaderboard(realm, minLevel)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to count leaderboard")
+			return
+		}
+
+		result = leaderboardQueryResult{entries: entries, total: total}
+		s.leaderboardCache.set(cacheKey, result)
+	}
+	entries, total := result.entries, result.total
+
+	etag := leaderboardETag(entries, total, offset, limit)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(leaderboardCacheMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	resp := leaderboardResponse{Entries: entries, Total: total, Offset: offset, Limit: limit}
+	if next := offset + len(entries); next < total {
+		resp.NextOffset = &next
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleGetLeaderboardAround returns a player's rank plus up to range
+// neighbors on either side, for a "you are here" view without the client
+// having to page through GetLeaderboard looking for itself.
+func (s *Server) handleGetLeaderboardAround(w http.ResponseWriter, r *http.Request) {
+	playerID := r.PathValue("playerID")
+	query := r.URL.Query()
+	realm := query.Get("realm")
+
+	rangeN := defaultLeaderboardAroundRange
+	if raw := query.Get("range"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeValidationError(w, r, "range", "range must be a positive integer")
+			return
+		}
+		rangeN = min(parsed, maxLeaderboardAroundRange)
+	}
+
+	sort := db.SortByKeystrokes
+	if raw := query.Get("sort"); raw != "" {
+		parsed, ok := leaderboardSorts[raw]
+		if !ok {
+			writeValidationError(w, r, "sort", "sort must be one of: keystrokes, words, programs")
+			return
+		}
+		sort = parsed
+	}
+
+	entries, err := s.db.GetLeaderboardAround(realm, sort, 0, playerID, rangeN)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codeLeaderboardNotFound, "player has no leaderboard entry")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load leaderboard")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, leaderboardResponse{Entries: entries, Limit: len(entries)})
+}
+
+// handlePutLeaderboardEntry submits a player's current stats to the
+// leaderboard. Unlike handlePutGameState, which a client is trusted to
+// report accurately about its own save, leaderboard standings are public
+// and competitive, so the submission is validated against the player's
+// own persisted GameState and account age before being accepted.
+func (s *Server) handlePutLeaderboardEntry(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+
+	var entry db.LeaderboardEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+
+	player, err := s.db.GetPlayer(id)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codePlayerNotFound, "player not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to look up player")
+		return
+	}
+
+	state, err := s.db.GetGameState(id, entry.Realm)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codeGameStateNotFound, "game state not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load game state")
+		return
+	}
+
+	entry.PlayerID = id
+	entry.Username = player.Username
+
+	if err := validateLeaderboardSubmission(&entry, state); err != nil {
+		writeValidationError(w, r, "leaderboard_entry", err.Error())
+		return
+	}
+
+	if err := s.db.UpdateLeaderboard(&entry); err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to update leaderboard")
+		return
+	}
+	s.leaderboardCache.invalidate()
+
+	writeJSON(w, http.StatusOK, entry)
+}
+
+// validateLeaderboardSubmission rejects leaderboard entries that couldn't
+// plausibly have come from state: submitted stats may not exceed the
+// player's own saved progress by more than elapsed time since that
+// progress was saved could account for, plus a small tolerance for sync
+// timing.
+func validateLeaderboardSubmission(entry *db.LeaderboardEntry, state *db.GameState) error {
+	if entry.Keystrokes < 0 || entry.Words < 0 || entry.Programs < 0 {
+		return errors.New("leaderboard stats must not be negative")
+	}
+
+	elapsed := time.Since(state.UpdatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	maxDelta := elapsed*maxPlausibleKeystrokesPerSecond + leaderboardSubmissionTolerance
+
+	if entry.Keystrokes > state.Keystrokes+maxDelta {
+		return errors.New("keystrokes exceed what's plausible since the player's last save")
+	}
+	if float64(entry.Words) > float64(state.Words)+maxDelta {
+		return errors.New("words exceed what's plausible since the player's last save")
+	}
+	if float64(entry.Programs) > float64(state.Programs)+maxDelta {
+		return errors.New("programs exceed what's plausible since the player's last save")
+	}
+
+	return nil
+}
+
+// leaderboardETag is a strong ETag derived from the page's last-modified
+// entry and the parameters that shape its content, so it changes whenever
+// either the underlying data or the requested page does.
+func leaderboardETag(entries []*db.LeaderboardEntry, total, offset, limit int) string {
+	var lastModified time.Time
+	for _, e := range entries {
+		if e.UpdatedAt.After(lastModified) {
+			lastModified = e.UpdatedAt
+		}
+	}
+	return fmt.Sprintf(`"%d-%d-%d-%d"`, offset, limit, total, lastModified.UnixNano())
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandlePutLeaderboardEntryAcceptsPlausibleSubmission(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 100, Words: 20, Programs: 1}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"keystrokes": 100, "words": 20, "programs": 1}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+}
+
+func TestHandlePutLeaderboardEntryRejectsSubmissionAboveGameState(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 100}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"keystrokes": 100000}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestHandlePutLeaderboardEntryRejectsOtherPlayersToken(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p2", Keystrokes: 100}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"keystrokes": 50}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p2/leaderboard", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleGetLeaderboardPaginates(t *testing.T) {
+	s := newTestServer(t)
+
+	for i, id := range []string{"p1", "p2", "p3"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		entry := &db.LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(100 - i)}
+		if err := s.db.UpdateLeaderboard(entry); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard?limit=2&offset=0", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp leaderboardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 2 {
+		t.Errorf("len(Entries) = %d, want 2", len(resp.Entries))
+	}
+	if resp.Total != 3 {
+		t.Errorf("Total = %d, want 3", resp.Total)
+	}
+	if resp.NextOffset == nil || *resp.NextOffset != 2 {
+		t.Errorf("NextOffset = %v, want 2", resp.NextOffset)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leaderboard?limit=2&offset=2", nil)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	resp = leaderboardResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 1 {
+		t.Errorf("len(Entries) = %d, want 1", len(resp.Entries))
+	}
+	if resp.NextOffset != nil {
+		t.Errorf("NextOffset = %v, want nil", resp.NextOffset)
+	}
+}
+
+func TestHandleGetLeaderboardFiltersByMinLevelAndSort(t *testing.T) {
+	s := newTestServer(t)
+
+	for i, id := range []string{"p1", "p2"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		entry := &db.LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(10 - i), Words: i + 1}
+		if err := s.db.UpdateLeaderboard(entry); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+		level := 10
+		if i == 1 {
+			level = 1
+		}
+		state := &db.GameState{PlayerID: id, CurrentLevel: level}
+		if err := s.db.SaveGameState(state); err != nil {
+			t.Fatalf("SaveGameState(%s) error = %v", id, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard?sort=words&min_level=5", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp leaderboardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].PlayerID != "p1" {
+		t.Fatalf("Entries = %+v, want [p1]", resp.Entries)
+	}
+}
+
+func TestHandleGetLeaderboardReturnsNotModifiedForMatchingETag(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.UpdateLeaderboard(&db.LeaderboardEntry{PlayerID: "p1", Username: "p1", Keystrokes: 10}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("response missing ETag header")
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc == "" {
+		t.Error("response missing Cache-Control header")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotModified)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want empty", rec.Body.String())
+	}
+}
+
+func TestHandleGetLeaderboardETagChangesWhenDataChanges(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "p1"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", Keystrokes: 1000}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	// Submit through the real write endpoint rather than calling
+	// s.db.UpdateLeaderboard directly, so this also exercises the
+	// leaderboard query cache's invalidation on writes.
+	submit := func(keystrokes float64) {
+		body := strings.NewReader(fmt.Sprintf(`{"keystrokes": %f}`, keystrokes))
+		req := httptest.NewRequest(http.MethodPut, "/api/players/p1/leaderboard", body)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("PUT status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+	}
+
+	submit(10)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	firstETag := rec.Header().Get("ETag")
+
+	time.Sleep(time.Millisecond)
+	submit(20)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	req.Header.Set("If-None-Match", firstETag)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestHandleGetLeaderboardAroundReturnsNeighbors(t *testing.T) {
+	s := newTestServer(t)
+
+	for i, id := range []string{"p1", "p2", "p3"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		entry := &db.LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(30 - i*10)}
+		if err := s.db.UpdateLeaderboard(entry); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard/around/p2?range=1", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp leaderboardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 3 {
+		t.Fatalf("len(Entries) = %d, want 3", len(resp.Entries))
+	}
+	if resp.Entries[1].PlayerID != "p2" || resp.Entries[1].Rank != 2 {
+		t.Errorf("Entries[1] = %+v, want p2 ranked 2", resp.Entries[1])
+	}
+}
+
+func TestHandleGetLeaderboardAroundReturnsNotFoundForUnrankedPlayer(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard/around/missing", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleGetLeaderboardRejectsInvalidSort(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard?sort=bogus", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleGetLeaderboardRejectsInvalidLimit(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard?limit=-1", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type requestIDContextKey struct{}
+
+// requestIDHeader is the response header callers can correlate against
+// their own logs.
+const requestIDHeader = "X-Request-Id"
+
+// requestID returns the request ID assigned to r by loggingMiddleware, or
+// "" if none was assigned (e.g. in a test calling a handler directly).
+func requestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code a
+// handler wrote, since http.ResponseWriter has no way to read it back.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// loggingMiddleware assigns each request a random ID, attaches it to the
+// request's context and response headers, and logs the method, path,
+// status, duration, and remote address once the handler returns.
+func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := newRequestID()
+		if err != nil {
+			id = "unknown"
+		}
+
+		ctx := context.WithValue(r.Context(), requestIDContextKey{}, id)
+		r = r.WithContext(ctx)
+		w.Header().Set(requestIDHeader, id)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+
+		slog.Info("http request",
+			"request_id", id,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", clientIP(r),
+		)
+	})
+}
+
+func newRequestID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
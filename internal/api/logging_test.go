@@ -0,0 +1,45 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoggingMiddlewareAssignsRequestID(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	id := rec.Header().Get(requestIDHeader)
+	if id == "" {
+		t.Fatal("response missing X-Request-Id header")
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body["request_id"] != id {
+		t.Errorf("body request_id = %q, want %q", body["request_id"], id)
+	}
+}
+
+func TestLoggingMiddlewareAssignsDistinctIDs(t *testing.T) {
+	s := newTestServer(t)
+
+	ids := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		ids[rec.Header().Get(requestIDHeader)] = true
+	}
+
+	if len(ids) != 3 {
+		t.Errorf("got %d distinct request IDs, want 3", len(ids))
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+type loginRequest struct {
+	APIToken string `json:"api_token"`
+}
+
+type loginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// handleLogin exchanges a long-lived API token for a short-lived JWT, so a
+// browser client can hold a session without storing the long-lived secret
+// in local storage.
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+	if req.APIToken == "" {
+		writeValidationError(w, r, "api_token", "api_token is required")
+		return
+	}
+
+	player, err := s.db.AuthenticateAPIToken(req.APIToken)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusUnauthorized, codeInvalidToken, "invalid API token")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to authenticate")
+		return
+	}
+
+	token, err := s.issueJWT(player.ID)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to issue session token")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, loginResponse{
+		Token:     token,
+		ExpiresAt: time.Now().Add(jwtTTL),
+	})
+}
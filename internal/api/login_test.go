@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleLoginExchangesAPITokenForJWT(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	apiToken, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"api_token": "` + apiToken + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", body)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp loginResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Token == "" {
+		t.Fatal("login response has empty token")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/players/p1/state", nil)
+	req.Header.Set("Authorization", "Bearer "+resp.Token)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status with JWT = %d, want %d (no game state saved yet)", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleLoginRejectsUnknownAPIToken(t *testing.T) {
+	s := newTestServer(t)
+
+	body := strings.NewReader(`{"api_token": "does-not-exist"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", body)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
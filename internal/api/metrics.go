@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// queryMetricsProvider is satisfied by *db.InstrumentedDB. Checking for it
+// with a type assertion, rather than adding QueryMetrics to the Database
+// interface itself, keeps every other implementation from having to grow a
+// method it has no metrics to report.
+type queryMetricsProvider interface {
+	QueryMetrics() map[string]db.MethodStats
+}
+
+// handleMetrics reports per-method database call counts, error rates, and
+// durations, for operators diagnosing slow or failing queries. It reports
+// an empty set rather than failing if s.db isn't wrapped in
+// db.InstrumentedDB.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	provider, ok := s.db.(queryMetricsProvider)
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]db.MethodStats{})
+		return
+	}
+	writeJSON(w, http.StatusOK, provider.QueryMetrics())
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleMetricsReportsEmptySetWithoutInstrumentation(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/metrics", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp map[string]db.MethodStats
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 0 {
+		t.Errorf("resp = %+v, want empty when s.db isn't instrumented", resp)
+	}
+}
+
+func TestHandleMetricsReportsCallCountsWhenInstrumented(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	sdb, err := db.NewSQLiteDB(path, db.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+
+	s := NewServer(db.NewInstrumentedDB(sdb))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	metricsReq := httptest.NewRequest(http.MethodGet, "/api/metrics", nil)
+	metricsRec := httptest.NewRecorder()
+	s.ServeHTTP(metricsRec, metricsReq)
+
+	var resp map[string]db.MethodStats
+	if err := json.NewDecoder(metricsRec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp["GetLeaderboard"].Calls != 1 {
+		t.Errorf("GetLeaderboard calls = %d, want 1 after handling /leaderboard", resp["GetLeaderboard"].Calls)
+	}
+}
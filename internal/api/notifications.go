@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// defaultNotificationsLimit bounds a single poll response, so a client that
+// passes a very old since timestamp (e.g. after a long time offline) can't
+// pull an unbounded backlog in one request.
+const defaultNotificationsLimit = 100
+
+type notificationsResponse struct {
+	Notifications []*db.Notification `json:"notifications"`
+}
+
+// handleGetPlayerNotifications returns everything persisted for a player
+// after the since query parameter, letting a client that was offline catch
+// up on what happened without re-deriving it from GameState.
+func (s *Server) handleGetPlayerNotifications(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+
+	since := time.Time{}
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			writeValidationError(w, r, "since", "since must be an RFC 3339 timestamp")
+			return
+		}
+		since = parsed
+	}
+
+	notifications, err := s.db.GetNotificationsSince(id, since, defaultNotificationsLimit)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load notifications")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, notificationsResponse{Notifications: notifications})
+}
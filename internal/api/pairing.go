@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+type claimPairingCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// handleClaimPairingCode binds a one-time pairing code, displayed to a
+// client authenticating over SSH without a key (e.g. a mobile SSH app with
+// no key agent), to the already-authenticated player making this request.
+// The waiting SSH session picks up the claim and completes as this player
+// once it notices.
+func (s *Server) handleClaimPairingCode(w http.ResponseWriter, r *http.Request) {
+	player := authenticatedPlayer(r)
+	if player == nil {
+		writeError(w, r, http.StatusUnauthorized, codeMissingToken, "missing bearer token")
+		return
+	}
+
+	var req claimPairingCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+	if req.Code == "" {
+		writeValidationError(w, r, "code", "code is required")
+		return
+	}
+
+	err := s.db.ClaimPairingCode(req.Code, player.ID)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codePairingCodeNotFound, "pairing code not found or expired")
+		return
+	}
+	if errors.Is(err, db.ErrAlreadyExists) {
+		writeError(w, r, http.StatusConflict, codePairingCodeClaimed, "pairing code has already been claimed")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to claim pairing code")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
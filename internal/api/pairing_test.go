@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleClaimPairingCodeBindsCodeToAuthenticatedPlayer(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+	code, err := s.db.IssuePairingCode()
+	if err != nil {
+		t.Fatalf("IssuePairingCode() error = %v", err)
+	}
+
+	body, _ := json.Marshal(claimPairingCodeRequest{Code: code})
+	req := httptest.NewRequest(http.MethodPost, "/api/pairing/claim", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	player, err := s.db.ResolvePairingCode(code)
+	if err != nil {
+		t.Fatalf("ResolvePairingCode() error = %v", err)
+	}
+	if player.ID != "p1" {
+		t.Errorf("ResolvePairingCode() player ID = %q, want p1", player.ID)
+	}
+}
+
+func TestHandleClaimPairingCodeRejectsUnknownCode(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body, _ := json.Marshal(claimPairingCodeRequest{Code: "NOTACODE"})
+	req := httptest.NewRequest(http.MethodPost, "/api/pairing/claim", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusNotFound, rec.Body.String())
+	}
+}
+
+func TestHandleClaimPairingCodeRequiresAuth(t *testing.T) {
+	s := newTestServer(t)
+
+	body, _ := json.Marshal(claimPairingCodeRequest{Code: "WHATEVER"})
+	req := httptest.NewRequest(http.MethodPost, "/api/pairing/claim", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusUnauthorized, rec.Body.String())
+	}
+}
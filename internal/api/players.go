@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// patchPlayerRequest carries the fields a player may update about their own
+// profile. Both fields are optional so a caller can change just one without
+// resending the other.
+type patchPlayerRequest struct {
+	Username  *string `json:"username"`
+	PublicKey *string `json:"public_key"`
+}
+
+// playerMetadata is the public view of a Player, omitting the SSH public
+// key other players/tools have no business seeing.
+type playerMetadata struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Realm     string    `json:"realm"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *Server) handleGetPlayer(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	player, err := s.db.GetPlayer(id)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codePlayerNotFound, "player not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to look up player")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, playerMetadata{
+		ID:        player.ID,
+		Username:  player.Username,
+		Realm:     player.Realm,
+		CreatedAt: player.CreatedAt,
+	})
+}
+
+func (s *Server) handlePatchPlayer(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+
+	var req patchPlayerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, codeInvalidBody, "invalid JSON body")
+		return
+	}
+	if req.Username == nil && req.PublicKey == nil {
+		writeValidationError(w, r, "username", "username or public_key is required")
+		return
+	}
+
+	errs := fieldErrors{}
+	if req.Username != nil {
+		if msg := validateUsername(*req.Username); msg != "" {
+			errs.add("username", msg)
+		}
+	}
+	if req.PublicKey != nil && *req.PublicKey == "" {
+		errs.add("public_key", "public_key must not be empty")
+	}
+	if !errs.ok() {
+		writeValidationErrors(w, r, errs)
+		return
+	}
+
+	if req.Username != nil {
+		if err := s.db.RenamePlayer(id, *req.Username); err != nil {
+			if errors.Is(err, db.ErrAlreadyExists) {
+				writeError(w, r, http.StatusConflict, codeUsernameTaken, "username already taken")
+				return
+			}
+			if errors.Is(err, db.ErrNotFound) {
+				writeError(w, r, http.StatusNotFound, codePlayerNotFound, "player not found")
+				return
+			}
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to rename player")
+			return
+		}
+	}
+
+	if req.PublicKey != nil {
+		if err := s.db.RotatePlayerKey(id, *req.PublicKey); err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				writeError(w, r, http.StatusNotFound, codePlayerNotFound, "player not found")
+				return
+			}
+			writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to rotate player key")
+			return
+		}
+	}
+
+	player, err := s.db.GetPlayer(id)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load updated player")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, playerMetadata{
+		ID:        player.ID,
+		Username:  player.Username,
+		Realm:     player.Realm,
+		CreatedAt: player.CreatedAt,
+	})
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandlePatchPlayerRenamesAndRotatesKey(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey", PublicKey: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"username": "typist", "public_key": "ssh-ed25519 BBBB"})
+	req := httptest.NewRequest(http.MethodPatch, "/api/players/p1", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	player, err := s.db.GetPlayer("p1")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if player.Username != "typist" {
+		t.Errorf("Username = %q, want %q", player.Username, "typist")
+	}
+	if player.PublicKey != "ssh-ed25519 BBBB" {
+		t.Errorf("PublicKey = %q, want %q", player.PublicKey, "ssh-ed25519 BBBB")
+	}
+}
+
+func TestHandlePatchPlayerRejectsOtherPlayersToken(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	token, err := s.db.IssueAPIToken("p2")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"username": "newname"})
+	req := httptest.NewRequest(http.MethodPatch, "/api/players/p1", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestHandlePatchPlayerRejectsInvalidUsernameCharset(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"username": "no spaces!"})
+	req := httptest.NewRequest(http.MethodPatch, "/api/players/p1", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+
+	var resp errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := resp.Details["username"]; !ok {
+		t.Errorf("Details = %+v, want a %q entry", resp.Details, "username")
+	}
+}
+
+func TestHandlePatchPlayerConflict(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SavePlayer(&db.Player{ID: "p2", Username: "typist"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"username": "typist"})
+	req := httptest.NewRequest(http.MethodPatch, "/api/players/p1", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
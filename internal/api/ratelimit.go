@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig controls a token bucket: RequestsPerSecond tokens refill
+// per second, up to Burst tokens held at once.
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// DefaultIPRateLimit is applied per client IP across the whole API, mainly
+// to slow down credential-stuffing attempts against /api/auth/login.
+func DefaultIPRateLimit() RateLimitConfig {
+	return RateLimitConfig{RequestsPerSecond: 5, Burst: 10}
+}
+
+// DefaultPlayerRateLimit is applied per authenticated player on mutating
+// endpoints, so one compromised or buggy client can't hammer the database.
+func DefaultPlayerRateLimit() RateLimitConfig {
+	return RateLimitConfig{RequestsPerSecond: 2, Burst: 5}
+}
+
+// rateLimiter hands out a token bucket per key, creating it lazily on first
+// use and keeping it for the life of the process.
+type rateLimiter struct {
+	cfg RateLimitConfig
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	return &rateLimiter{cfg: cfg, limiters: make(map[string]*rate.Limiter)}
+}
+
+// allow reports whether a request for key may proceed. If not, it also
+// returns how long the caller should wait before retrying.
+func (rl *rateLimiter) allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	limiter, ok := rl.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(rl.cfg.RequestsPerSecond), rl.cfg.Burst)
+		rl.limiters[key] = limiter
+	}
+	rl.mu.Unlock()
+
+	reservation := limiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+func tooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", int(retryAfter.Seconds())+1))
+	writeError(w, r, http.StatusTooManyRequests, codeRateLimited, "rate limit exceeded")
+}
+
+// rateLimitByIP throttles requests per client IP, regardless of whether the
+// caller has authenticated yet.
+func (s *Server) rateLimitByIP(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.cfg.EnableRateLimit {
+			next(w, r)
+			return
+		}
+
+		ip := clientIP(r)
+		if ok, retryAfter := s.ipLimiter.allow(ip); !ok {
+			tooManyRequests(w, r, retryAfter)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// rateLimitByPlayer throttles requests per authenticated player. It must run
+// after requireAuth has attached a player to the request context.
+func (s *Server) rateLimitByPlayer(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.cfg.EnableRateLimit {
+			next(w, r)
+			return
+		}
+
+		player := authenticatedPlayer(r)
+		if player == nil {
+			next(w, r)
+			return
+		}
+		if ok, retryAfter := s.playerLimiter.allow(player.ID); !ok {
+			tooManyRequests(w, r, retryAfter)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,165 @@
+// Package api exposes Term Idle's player and leaderboard data over HTTP,
+// for external tools and a future web client that can't speak SSH.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// Version is the build version reported by the health endpoint. It is
+// overridden at build time via -ldflags "-X .../api.Version=...";
+// unset builds (e.g. go run) report "dev".
+var Version = "dev"
+
+// Server serves the REST API backed by a Database.
+type Server struct {
+	db        db.Database
+	cfg       ServerConfig
+	jwtSecret []byte
+	mux       *http.ServeMux
+	handler   http.Handler
+	startedAt time.Time
+
+	ipLimiter     *rateLimiter
+	playerLimiter *rateLimiter
+	abuseGuard    *abuseGuard
+
+	leaderboardCache *queryCache
+	globalStatsCache *queryCache
+	idempotencyStore *idempotencyStore
+
+	sshAddr string
+
+	httpServer *http.Server
+}
+
+// NewServer builds a Server with all routes registered and every
+// protection (auth, rate limiting, CORS, compression) enabled.
+func NewServer(database db.Database) *Server {
+	return NewServerWithConfig(database, DefaultServerConfig())
+}
+
+// NewServerWithConfig builds a Server like NewServer, but lets the caller
+// turn off individual pieces of the middleware chain, for embedding this
+// API inside a host that already handles them itself.
+func NewServerWithConfig(database db.Database, cfg ServerConfig) *Server {
+	secret, err := newJWTSecret()
+	if err != nil {
+		// Only fails if the system RNG is broken, which nothing downstream
+		// could recover from either.
+		panic(err)
+	}
+
+	s := &Server{
+		db:            database,
+		cfg:           cfg,
+		jwtSecret:     secret,
+		mux:           http.NewServeMux(),
+		startedAt:     time.Now(),
+		ipLimiter:     newRateLimiter(DefaultIPRateLimit()),
+		playerLimiter: newRateLimiter(DefaultPlayerRateLimit()),
+		abuseGuard:    newAbuseGuard(cfg.AbuseProtection),
+
+		leaderboardCache: newQueryCache(hotQueryCacheTTL),
+		globalStatsCache: newQueryCache(hotQueryCacheTTL),
+		idempotencyStore: newIdempotencyStore(),
+	}
+	s.routes()
+
+	s.handler = http.Handler(s.mux)
+	if cfg.EnableCompression {
+		s.handler = s.gzipMiddleware(s.handler)
+	}
+	if cfg.EnableCORS {
+		s.handler = s.corsMiddleware(s.handler)
+	}
+	s.handler = s.loggingMiddleware(s.handler)
+	if cfg.EnableAbuseProtection {
+		s.handler = s.abuseMiddleware(s.handler)
+	}
+	return s
+}
+
+// Router returns the fully assembled handler, including every enabled
+// middleware, so a host application can mount the API inside its own mux
+// (e.g. router.Handle("/term-idle/", server.Router())) instead of calling
+// Start.
+func (s *Server) Router() http.Handler {
+	return s.handler
+}
+
+// SetSSHAddr tells the health check to also verify that the SSH gateway is
+// accepting connections at addr. Leave unset if this process doesn't run
+// the SSH gateway, in which case the health check omits SSH entirely.
+func (s *Server) SetSSHAddr(addr string) {
+	s.sshAddr = addr
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Start listens on addr and blocks until the server stops. Call Shutdown
+// from another goroutine to stop it gracefully; Start then returns
+// http.ErrServerClosed, which callers should not treat as a failure.
+func (s *Server) Start(addr string) error {
+	s.httpServer = &http.Server{
+		Addr:           addr,
+		Handler:        s,
+		ReadTimeout:    s.cfg.ReadTimeout,
+		WriteTimeout:   s.cfg.WriteTimeout,
+		IdleTimeout:    s.cfg.IdleTimeout,
+		MaxHeaderBytes: s.cfg.MaxHeaderBytes,
+	}
+	return s.httpServer.ListenAndServe()
+}
+
+// Shutdown stops Start from accepting new connections and waits for
+// in-flight requests to finish or ctx to expire, whichever comes first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.handle("GET", "/health", s.handleHealth)
+	s.handle("GET", "/healthz", s.handleLiveness)
+	s.handle("GET", "/readyz", s.handleReadiness)
+	s.handle("GET", "/metrics", s.handleMetrics)
+	s.handle("POST", "/auth/login", s.rateLimitByIP(s.handleLogin))
+	s.handle("GET", "/leaderboard", s.handleGetLeaderboard)
+	s.handle("GET", "/stats", s.handleGetGlobalStats)
+	s.handle("GET", "/leaderboard/around/{playerID}", s.handleGetLeaderboardAround)
+	s.handle("GET", "/players/{id}", s.handleGetPlayer)
+	s.handle("PATCH", "/players/{id}", s.requireAuth(s.idempotent(s.handlePatchPlayer)))
+	s.handle("GET", "/players/{id}/state", s.requireAuth(s.handleGetGameState))
+	s.handle("GET", "/players/{id}/story", s.requireAuth(s.handleGetPlayerStory))
+	s.handle("GET", "/players/{id}/notifications", s.requireAuth(s.handleGetPlayerNotifications))
+	s.handle("GET", "/players/{id}/export", s.requireAuth(s.handleGetPlayerExport))
+	s.handle("POST", "/players/{id}/import", s.requireAuth(s.rateLimitByPlayer(s.idempotent(s.handlePostPlayerImport))))
+	s.handle("PUT", "/players/{id}/state", s.requireAuth(s.rateLimitByPlayer(s.idempotent(s.handlePutGameState))))
+	s.handle("PUT", "/players/{id}/leaderboard", s.requireAuth(s.rateLimitByPlayer(s.idempotent(s.handlePutLeaderboardEntry))))
+	s.handle("POST", "/graphql", s.rateLimitByIP(s.handleGraphQL))
+	s.handle("POST", "/pairing/claim", s.requireAuth(s.handleClaimPairingCode))
+}
+
+// handle registers a route under the current, versioned API (/api/v1/...)
+// and, as a compatibility shim, under the unversioned /api/... path it
+// replaces. This lets /api/v2 introduce breaking response shapes later
+// without pulling the rug out from under clients still on /api or /api/v1.
+func (s *Server) handle(method, path string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(method+" /api"+path, handler)
+	s.mux.HandleFunc(method+" /api/v1"+path, handler)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
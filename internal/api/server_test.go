@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	sdb, err := db.NewSQLiteDB(path, db.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+
+	return NewServer(sdb)
+}
+
+func TestServerShutdownDrainsInFlightRequests(t *testing.T) {
+	s := newTestServer(t)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start("127.0.0.1:0") }()
+
+	// Start binds asynchronously; give it a moment before shutting down.
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		t.Fatalf("Start() error = %v, want http.ErrServerClosed", err)
+	}
+}
+
+func TestStartAppliesConfiguredTimeouts(t *testing.T) {
+	s := newTestServer(t)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start("127.0.0.1:0") }()
+	time.Sleep(50 * time.Millisecond)
+
+	if s.httpServer.ReadTimeout != DefaultServerConfig().ReadTimeout {
+		t.Errorf("ReadTimeout = %v, want %v", s.httpServer.ReadTimeout, DefaultServerConfig().ReadTimeout)
+	}
+	if s.httpServer.WriteTimeout != DefaultServerConfig().WriteTimeout {
+		t.Errorf("WriteTimeout = %v, want %v", s.httpServer.WriteTimeout, DefaultServerConfig().WriteTimeout)
+	}
+	if s.httpServer.IdleTimeout != DefaultServerConfig().IdleTimeout {
+		t.Errorf("IdleTimeout = %v, want %v", s.httpServer.IdleTimeout, DefaultServerConfig().IdleTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		t.Fatalf("Start() error = %v, want http.ErrServerClosed", err)
+	}
+}
+
+func TestRoutesServeBothVersionedAndLegacyPaths(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, path := range []string{"/api/leaderboard", "/api/v1/leaderboard"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: status = %d, want %d", path, rec.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestHandleGetPlayerNotFound(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/missing", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleGetGameStateRequiresAuth(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/p1/state", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestHandlePutThenGetGameStateRoundTrips(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"current_level": 3, "keystrokes": 100}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p1/state", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/players/p1/state", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"current_level":3`) {
+		t.Errorf("GET body = %s, want current_level 3", rec.Body.String())
+	}
+}
+
+func TestHandlePutGameStateRejectsNegativeValues(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"current_level": -1}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p1/state", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandlePutGameStateReportsEveryViolationAtOnce(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	body := strings.NewReader(`{"current_level": 101, "keystrokes": -5}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/players/p1/state", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+
+	var resp errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, field := range []string{"current_level", "keystrokes"} {
+		if _, ok := resp.Details[field]; !ok {
+			t.Errorf("Details = %+v, want a %q entry", resp.Details, field)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// storyResponse reports which story chapters a player has unlocked and how
+// far through the overall narrative they are.
+type storyResponse struct {
+	Chapters        []game.Chapter `json:"chapters"`
+	UnlockedCount   int            `json:"unlocked_count"`
+	TotalChapters   int            `json:"total_chapters"`
+	ProgressPercent float64        `json:"progress_percent"`
+}
+
+func (s *Server) handleGetPlayerStory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if player := authenticatedPlayer(r); player != nil && player.ID != id {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "token does not belong to this player")
+		return
+	}
+	realm := r.URL.Query().Get("realm")
+
+	state, err := s.db.GetGameState(id, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, codeGameStateNotFound, "game state not found")
+		return
+	}
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, codeInternal, "failed to load game state")
+		return
+	}
+
+	unlocked := game.UnlockedChapters(state.CurrentLevel)
+	writeJSON(w, http.StatusOK, storyResponse{
+		Chapters:        unlocked,
+		UnlockedCount:   len(unlocked),
+		TotalChapters:   len(game.Chapters()),
+		ProgressPercent: game.StoryProgressPercent(state.CurrentLevel),
+	})
+}
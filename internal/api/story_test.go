@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestHandleGetPlayerStoryReturnsUnlockedChapters(t *testing.T) {
+	s := newTestServer(t)
+
+	if err := s.db.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := s.db.SaveGameState(&db.GameState{PlayerID: "p1", CurrentLevel: 10}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	token, err := s.db.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/p1/story", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp storyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.UnlockedCount != 3 {
+		t.Errorf("UnlockedCount = %d, want 3", resp.UnlockedCount)
+	}
+	if resp.TotalChapters <= resp.UnlockedCount {
+		t.Errorf("TotalChapters = %d, want more than UnlockedCount %d", resp.TotalChapters, resp.UnlockedCount)
+	}
+}
+
+func TestHandleGetPlayerStoryRejectsOtherPlayersToken(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := s.db.SavePlayer(&db.Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	token, err := s.db.IssueAPIToken("p2")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/players/p1/story", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
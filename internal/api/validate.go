@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// fieldErrors collects every validation violation found in a request body,
+// keyed by field name, so a client can fix all of them from a single
+// response instead of playing whack-a-mole with one error per request.
+type fieldErrors map[string]string
+
+func (fe fieldErrors) add(field, message string) {
+	fe[field] = message
+}
+
+func (fe fieldErrors) ok() bool {
+	return len(fe) == 0
+}
+
+// writeValidationErrors writes a VALIDATION_FAILED error envelope carrying
+// every violation in errs.
+func writeValidationErrors(w http.ResponseWriter, r *http.Request, errs fieldErrors) {
+	writeJSON(w, http.StatusBadRequest, errorResponse{
+		Error:     "validation failed",
+		Code:      codeValidationFailed,
+		RequestID: requestID(r),
+		Details:   errs,
+	})
+}
+
+// writeValidationError writes a VALIDATION_FAILED error envelope for a
+// single field, for checks that don't have other violations to report
+// alongside.
+func writeValidationError(w http.ResponseWriter, r *http.Request, field, message string) {
+	writeValidationErrors(w, r, fieldErrors{field: message})
+}
+
+// usernamePattern restricts usernames to characters that are safe to
+// display and to use in URLs, without resorting to percent-encoding.
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,32}$`)
+
+// validateUsername returns a message describing why username is invalid,
+// or "" if it's acceptable.
+func validateUsername(username string) string {
+	if !usernamePattern.MatchString(username) {
+		return "username must be 3-32 characters and contain only letters, digits, underscores, and hyphens"
+	}
+	return ""
+}
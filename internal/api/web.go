@@ -0,0 +1,17 @@
+package api
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:embed web/index.html
+var webFS embed.FS
+
+// handleIndex serves a small static page that renders the live
+// leaderboard and health status from the API, so operators get a
+// shareable web view without standing up a separate frontend.
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	http.ServeFileFS(w, r, webFS, "web/index.html")
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordAuthAttempt appends one entry to the authentication audit log,
+// regardless of whether the attempt succeeded.
+func (s *SQLiteDB) RecordAuthAttempt(username, fingerprint, sourceIP string, success bool, reason string) error {
+	_, err := s.conn.Exec(
+		`INSERT INTO auth_audit_log (username, fingerprint, source_ip, success, reason, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		username, fingerprint, sourceIP, success, reason, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record auth attempt for %s: %w", username, err)
+	}
+	return nil
+}
+
+// GetAuthAttempts returns the most recent auth attempts for username,
+// newest first, capped at limit, for operators reviewing a specific
+// account's login history.
+func (s *SQLiteDB) GetAuthAttempts(username string, limit int) ([]*AuthAttempt, error) {
+	rows, err := s.conn.Query(
+		`SELECT id, username, fingerprint, source_ip, success, reason, created_at
+		 FROM auth_audit_log WHERE username = ? ORDER BY id DESC LIMIT ?`,
+		username, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query auth attempts for %s: %w", username, err)
+	}
+	defer rows.Close()
+
+	var attempts []*AuthAttempt
+	for rows.Next() {
+		var a AuthAttempt
+		if err := rows.Scan(&a.ID, &a.Username, &a.Fingerprint, &a.SourceIP, &a.Success, &a.Reason, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan auth attempt row: %w", err)
+		}
+		attempts = append(attempts, &a)
+	}
+
+	return attempts, rows.Err()
+}
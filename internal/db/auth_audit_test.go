@@ -0,0 +1,55 @@
+package db
+
+import "testing"
+
+func TestRecordAndGetAuthAttempts(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.RecordAuthAttempt("monkey", "SHA256:abc", "203.0.113.5", true, ""); err != nil {
+		t.Fatalf("RecordAuthAttempt() error = %v", err)
+	}
+	if err := sdb.RecordAuthAttempt("monkey", "SHA256:def", "203.0.113.6", false, "public key does not match"); err != nil {
+		t.Fatalf("RecordAuthAttempt() error = %v", err)
+	}
+
+	attempts, err := sdb.GetAuthAttempts("monkey", 10)
+	if err != nil {
+		t.Fatalf("GetAuthAttempts() error = %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("GetAuthAttempts() returned %d attempts, want 2", len(attempts))
+	}
+	if attempts[0].Fingerprint != "SHA256:def" || attempts[0].Success {
+		t.Errorf("GetAuthAttempts()[0] = %+v, want the most recent (failed) attempt first", attempts[0])
+	}
+}
+
+func TestGetAuthAttemptsRespectsLimit(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for i := 0; i < 5; i++ {
+		if err := sdb.RecordAuthAttempt("monkey", "SHA256:abc", "203.0.113.5", true, ""); err != nil {
+			t.Fatalf("RecordAuthAttempt() error = %v", err)
+		}
+	}
+
+	attempts, err := sdb.GetAuthAttempts("monkey", 2)
+	if err != nil {
+		t.Fatalf("GetAuthAttempts() error = %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("GetAuthAttempts() returned %d attempts, want 2", len(attempts))
+	}
+}
+
+func TestGetAuthAttemptsForUnknownUsername(t *testing.T) {
+	sdb := newTestDB(t)
+
+	attempts, err := sdb.GetAuthAttempts("nobody", 10)
+	if err != nil {
+		t.Fatalf("GetAuthAttempts() error = %v", err)
+	}
+	if len(attempts) != 0 {
+		t.Fatalf("GetAuthAttempts() = %+v, want none", attempts)
+	}
+}
@@ -0,0 +1,24 @@
+package db
+
+import "testing"
+
+func TestSaveGameStateRoundTripsNotifications(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	notifications := []string{"level up!", "new upgrade available"}
+	if err := sdb.SaveGameState(&GameState{PlayerID: "p1", Notifications: notifications}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	got, err := sdb.GetGameState("p1", "")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if len(got.Notifications) != 2 || got.Notifications[0] != "level up!" {
+		t.Errorf("GetGameState().Notifications = %v, want %v", got.Notifications, notifications)
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// AddConnectionBan blocks new SSH connections matching value (a CIDR range
+// for kind "cidr", or an exact fingerprint for kind "key"), replacing any
+// existing ban on the same value.
+func (s *SQLiteDB) AddConnectionBan(value, kind, reason string) error {
+	_, err := s.conn.Exec(
+		`INSERT INTO connection_bans (value, kind, reason, created_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(value) DO UPDATE SET kind = excluded.kind, reason = excluded.reason, created_at = excluded.created_at`,
+		value, kind, reason, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add connection ban for %s: %w", value, err)
+	}
+	return nil
+}
+
+// RemoveConnectionBan lifts a ban previously added with AddConnectionBan.
+func (s *SQLiteDB) RemoveConnectionBan(value string) error {
+	res, err := s.conn.Exec(`DELETE FROM connection_bans WHERE value = ?`, value)
+	if err != nil {
+		return fmt.Errorf("failed to remove connection ban for %s: %w", value, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected removing connection ban for %s: %w", value, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("connection ban %s: %w", value, ErrNotFound)
+	}
+	return nil
+}
+
+// ListConnectionBans returns every active ban, for the SSH gateway's
+// connection filter to check incoming connections against.
+func (s *SQLiteDB) ListConnectionBans() ([]*ConnectionBan, error) {
+	rows, err := s.conn.Query(`SELECT value, kind, reason, created_at FROM connection_bans`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connection bans: %w", err)
+	}
+	defer rows.Close()
+
+	var bans []*ConnectionBan
+	for rows.Next() {
+		var b ConnectionBan
+		if err := rows.Scan(&b.Value, &b.Kind, &b.Reason, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan connection ban row: %w", err)
+		}
+		bans = append(bans, &b)
+	}
+
+	return bans, rows.Err()
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddAndListConnectionBans(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.AddConnectionBan("203.0.113.0/24", "cidr", "spam"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+	if err := sdb.AddConnectionBan("SHA256:abc", "key", "compromised key"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+
+	bans, err := sdb.ListConnectionBans()
+	if err != nil {
+		t.Fatalf("ListConnectionBans() error = %v", err)
+	}
+	if len(bans) != 2 {
+		t.Fatalf("ListConnectionBans() returned %d bans, want 2", len(bans))
+	}
+}
+
+func TestAddConnectionBanReplacesExisting(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.AddConnectionBan("203.0.113.0/24", "cidr", "first reason"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+	if err := sdb.AddConnectionBan("203.0.113.0/24", "cidr", "updated reason"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+
+	bans, err := sdb.ListConnectionBans()
+	if err != nil {
+		t.Fatalf("ListConnectionBans() error = %v", err)
+	}
+	if len(bans) != 1 || bans[0].Reason != "updated reason" {
+		t.Fatalf("ListConnectionBans() = %+v, want a single ban with the updated reason", bans)
+	}
+}
+
+func TestRemoveConnectionBan(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.AddConnectionBan("203.0.113.0/24", "cidr", "spam"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+	if err := sdb.RemoveConnectionBan("203.0.113.0/24"); err != nil {
+		t.Fatalf("RemoveConnectionBan() error = %v", err)
+	}
+
+	bans, err := sdb.ListConnectionBans()
+	if err != nil {
+		t.Fatalf("ListConnectionBans() error = %v", err)
+	}
+	if len(bans) != 0 {
+		t.Fatalf("ListConnectionBans() = %+v, want none", bans)
+	}
+}
+
+func TestRemoveConnectionBanNotFound(t *testing.T) {
+	sdb := newTestDB(t)
+
+	err := sdb.RemoveConnectionBan("203.0.113.0/24")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("RemoveConnectionBan() error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,69 @@
+// Package db provides the persistence layer for Term Idle.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Database is the interface the rest of the application uses to persist
+// and query player data. SQLiteDB is the only implementation today, but
+// callers should depend on this interface rather than the concrete type.
+type Database interface {
+	GetPlayer(id string) (*Player, error)
+	GetPlayerByUsername(username string) (*Player, error)
+	GetPlayerByPublicKey(publicKey string) (*Player, error)
+	SavePlayer(player *Player) error
+	CreateOrGetPlayer(id, username, publicKey, realm string) (*Player, error)
+	CreateOrGetPlayerByKey(id, username, publicKey, realm string) (*Player, error)
+	DeletePlayer(id string) error
+	SetPlayerBanned(id string, banned bool) error
+	SetPlayerSuspended(id string, suspended bool) error
+	RenamePlayer(id, username string) error
+	RotatePlayerKey(id, publicKey string) error
+	UpdateLastActive(id string) error
+	StartSession(playerID, sourceIP string) (int64, error)
+	EndSession(id int64, bytesIn, bytesOut int64) error
+	GetLastSession(playerID string) (*SessionRecord, error)
+	Ping(ctx context.Context) error
+	Stats() sql.DBStats
+	SearchPlayers(query string, limit int) ([]*Player, error)
+	GetGameState(playerID, realm string) (*GameState, error)
+	SaveGameState(state *GameState) error
+	PurchaseUpgrade(playerID, upgradeType string, level int, state *GameState) error
+	GetUpgradeLevels(playerID string) (map[string]int, error)
+	GetLifetimeStats(playerID string) (*LifetimeStats, error)
+	AddLifetimeStats(playerID string, delta LifetimeStats) error
+	SendFriendRequest(fromID, toID string) error
+	AcceptFriendRequest(playerID, requesterID string) error
+	RemoveFriend(playerID, friendID string) error
+	GetFriends(playerID string) ([]*Friend, error)
+	CreateGuild(guild *Guild) error
+	GetGuild(id string) (*Guild, error)
+	JoinGuild(guildID, playerID string) error
+	LeaveGuild(guildID, playerID string) error
+	GetGuildMembers(guildID string) ([]*GuildMember, error)
+	GetLeaderboard(realm string, sort LeaderboardSort, minLevel, limit, offset int) ([]*LeaderboardEntry, error)
+	CountLeaderboard(realm string, minLevel int) (int, error)
+	GetLeaderboardAround(realm string, sort LeaderboardSort, minLevel int, playerID string, rangeN int) ([]*LeaderboardEntry, error)
+	UpdateLeaderboard(entry *LeaderboardEntry) error
+	GetGlobalStats() (*GlobalStats, error)
+	AddConnectionBan(value, kind, reason string) error
+	RemoveConnectionBan(value string) error
+	ListConnectionBans() ([]*ConnectionBan, error)
+	RecordAuthAttempt(username, fingerprint, sourceIP string, success bool, reason string) error
+	GetAuthAttempts(username string, limit int) ([]*AuthAttempt, error)
+	GetReplicationLog(afterID int64, limit int) ([]*ReplicationEvent, error)
+	PruneReplicationLog(throughID int64) error
+	AddNotification(playerID, realm, message, severity string) error
+	GetNotificationsSince(playerID string, since time.Time, limit int) ([]*Notification, error)
+	IssueAPIToken(playerID string) (string, error)
+	RotateAPIToken(playerID string) (string, error)
+	RevokeAPIToken(token string) error
+	AuthenticateAPIToken(token string) (*Player, error)
+	IssuePairingCode() (string, error)
+	ClaimPairingCode(code, playerID string) error
+	ResolvePairingCode(code string) (*Player, error)
+	Close() error
+}
@@ -0,0 +1,30 @@
+//go:build !sqlcipher
+
+package db
+
+import (
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const encryptionSupported = false
+
+// newConnector builds a connector whose ConnectHook applies opts' pragmas
+// to every connection go-sqlite3 opens for the pool, not just whichever one
+// happens to service the first query.
+func newConnector(path string, opts Options) *sqliteConnector {
+	return &sqliteConnector{
+		dsn: path,
+		driver: &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				for _, pragma := range pragmaStatements(opts) {
+					if _, err := conn.Exec(pragma, nil); err != nil {
+						return fmt.Errorf("failed to apply pragma %q: %w", pragma, err)
+					}
+				}
+				return nil
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+//go:build sqlcipher
+
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mutecomm/go-sqlcipher/v4"
+)
+
+const encryptionSupported = true
+
+// newConnector builds a connector whose ConnectHook sets the encryption key
+// (if any) and applies opts' pragmas to every connection the pool opens,
+// not just whichever one happens to service the first query. The key must
+// be set first on each connection: SQLCipher needs it to decrypt the header
+// of an existing file, or to encrypt a new one, before anything else can
+// touch that connection.
+func newConnector(path string, opts Options) *sqliteConnector {
+	key := opts.EncryptionKey
+	return &sqliteConnector{
+		dsn: path,
+		driver: &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				if key != "" {
+					// PRAGMA doesn't support bound parameters, so quote the
+					// key by hand.
+					escapedKey := strings.ReplaceAll(key, "'", "''")
+					if _, err := conn.Exec(fmt.Sprintf("PRAGMA key = '%s';", escapedKey), nil); err != nil {
+						return fmt.Errorf("failed to set encryption key: %w", err)
+					}
+				}
+				for _, pragma := range pragmaStatements(opts) {
+					if _, err := conn.Exec(pragma, nil); err != nil {
+						return fmt.Errorf("failed to apply pragma %q: %w", pragma, err)
+					}
+				}
+				return nil
+			},
+		},
+	}
+}
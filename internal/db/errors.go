@@ -0,0 +1,14 @@
+package db
+
+import "errors"
+
+// Sentinel errors returned by this package. Callers should use errors.Is
+// rather than comparing against database/sql errors directly, since the
+// underlying driver is an implementation detail.
+var (
+	// ErrNotFound is returned when a lookup finds no matching row.
+	ErrNotFound = errors.New("db: not found")
+	// ErrAlreadyExists is returned when a create would violate a uniqueness
+	// constraint.
+	ErrAlreadyExists = errors.New("db: already exists")
+)
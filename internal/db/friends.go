@@ -0,0 +1,98 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// SendFriendRequest records a pending friend request from fromID to toID.
+func (s *SQLiteDB) SendFriendRequest(fromID, toID string) error {
+	_, err := s.conn.Exec(
+		`INSERT INTO friendships (player_id, friend_id, status, created_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(player_id, friend_id) DO NOTHING`,
+		fromID, toID, FriendStatusPending, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to send friend request from %s to %s: %w", fromID, toID, err)
+	}
+
+	return nil
+}
+
+// AcceptFriendRequest turns requesterID's pending request to playerID into a
+// mutual friendship, visible from both players' friend lists.
+func (s *SQLiteDB) AcceptFriendRequest(playerID, requesterID string) error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	_, err = tx.Exec(
+		`UPDATE friendships SET status = ? WHERE player_id = ? AND friend_id = ?`,
+		FriendStatusAccepted, requesterID, playerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to accept friend request from %s: %w", requesterID, err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO friendships (player_id, friend_id, status, created_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT(player_id, friend_id) DO UPDATE SET status = excluded.status`,
+		playerID, requesterID, FriendStatusAccepted, now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record reciprocal friendship for %s: %w", playerID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit friend acceptance: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveFriend deletes the friendship between two players in both directions.
+func (s *SQLiteDB) RemoveFriend(playerID, friendID string) error {
+	_, err := s.conn.Exec(
+		`DELETE FROM friendships
+		 WHERE (player_id = ? AND friend_id = ?) OR (player_id = ? AND friend_id = ?)`,
+		playerID, friendID, friendID, playerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove friendship between %s and %s: %w", playerID, friendID, err)
+	}
+
+	return nil
+}
+
+// GetFriends returns everyone in playerID's friend list, including pending
+// incoming and outgoing requests.
+func (s *SQLiteDB) GetFriends(playerID string) ([]*Friend, error) {
+	rows, err := s.conn.Query(
+		`SELECT p.id, p.username, f.status, f.created_at
+		 FROM friendships f
+		 JOIN players p ON p.id = f.friend_id
+		 WHERE f.player_id = ?
+		 ORDER BY f.created_at`,
+		playerID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friends for %s: %w", playerID, err)
+	}
+	defer rows.Close()
+
+	var friends []*Friend
+	for rows.Next() {
+		var f Friend
+		if err := rows.Scan(&f.PlayerID, &f.Username, &f.Status, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan friend row: %w", err)
+		}
+		friends = append(friends, &f)
+	}
+
+	return friends, rows.Err()
+}
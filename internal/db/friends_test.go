@@ -0,0 +1,71 @@
+package db
+
+import "testing"
+
+func TestFriendRequestAcceptIsMutual(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for _, id := range []string{"alice", "bob"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+
+	if err := sdb.SendFriendRequest("alice", "bob"); err != nil {
+		t.Fatalf("SendFriendRequest() error = %v", err)
+	}
+	if err := sdb.AcceptFriendRequest("bob", "alice"); err != nil {
+		t.Fatalf("AcceptFriendRequest() error = %v", err)
+	}
+
+	aliceFriends, err := sdb.GetFriends("alice")
+	if err != nil {
+		t.Fatalf("GetFriends(alice) error = %v", err)
+	}
+	if len(aliceFriends) != 1 || aliceFriends[0].PlayerID != "bob" || aliceFriends[0].Status != FriendStatusAccepted {
+		t.Fatalf("GetFriends(alice) = %+v, want [bob accepted]", aliceFriends)
+	}
+
+	bobFriends, err := sdb.GetFriends("bob")
+	if err != nil {
+		t.Fatalf("GetFriends(bob) error = %v", err)
+	}
+	if len(bobFriends) != 1 || bobFriends[0].PlayerID != "alice" || bobFriends[0].Status != FriendStatusAccepted {
+		t.Fatalf("GetFriends(bob) = %+v, want [alice accepted]", bobFriends)
+	}
+}
+
+func TestRemoveFriendRemovesBothDirections(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for _, id := range []string{"alice", "bob"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	if err := sdb.SendFriendRequest("alice", "bob"); err != nil {
+		t.Fatalf("SendFriendRequest() error = %v", err)
+	}
+	if err := sdb.AcceptFriendRequest("bob", "alice"); err != nil {
+		t.Fatalf("AcceptFriendRequest() error = %v", err)
+	}
+
+	if err := sdb.RemoveFriend("alice", "bob"); err != nil {
+		t.Fatalf("RemoveFriend() error = %v", err)
+	}
+
+	aliceFriends, err := sdb.GetFriends("alice")
+	if err != nil {
+		t.Fatalf("GetFriends(alice) error = %v", err)
+	}
+	if len(aliceFriends) != 0 {
+		t.Errorf("GetFriends(alice) = %+v, want none", aliceFriends)
+	}
+	bobFriends, err := sdb.GetFriends("bob")
+	if err != nil {
+		t.Fatalf("GetFriends(bob) error = %v", err)
+	}
+	if len(bobFriends) != 0 {
+		t.Errorf("GetFriends(bob) = %+v, want none", bobFriends)
+	}
+}
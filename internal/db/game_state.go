@@ -0,0 +1,102 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GetGameState loads a player's persisted progress within realm. Realms are
+// fully independent worlds, so the same player can hold a different
+// GameState in each one.
+func (s *SQLiteDB) GetGameState(playerID, realm string) (*GameState, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	row := s.conn.QueryRow(
+		`SELECT player_id, realm, current_level, keystrokes, words, programs,
+		        ai_automations, story_progress, production_rate, notifications, updated_at
+		 FROM game_states WHERE player_id = ? AND realm = ?`,
+		playerID, realm,
+	)
+
+	var gs GameState
+	var notifications []byte
+	err := row.Scan(
+		&gs.PlayerID, &gs.Realm, &gs.CurrentLevel, &gs.Keystrokes, &gs.Words,
+		&gs.Programs, &gs.AIAutomations, &gs.StoryProgress,
+		&gs.ProductionRate, &notifications, &gs.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("game state %s/%s: %w", realm, playerID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game state %s/%s: %w", realm, playerID, err)
+	}
+	if err := decompressJSON(notifications, &gs.Notifications); err != nil {
+		return nil, fmt.Errorf("failed to decode notifications for %s/%s: %w", realm, playerID, err)
+	}
+
+	levels, err := s.GetUpgradeLevels(playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade levels for %s/%s: %w", realm, playerID, err)
+	}
+	gs.UpgradeLevels = levels
+
+	return &gs, nil
+}
+
+// SaveGameState upserts a player's progress within state.Realm.
+func (s *SQLiteDB) SaveGameState(state *GameState) error {
+	if err := saveGameState(s.conn, state); err != nil {
+		return fmt.Errorf("failed to save game state %s/%s: %w", state.Realm, state.PlayerID, err)
+	}
+	return nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx, letting saveGameState be
+// shared between a plain save and a transactional one (see PurchaseUpgrade).
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx, letting a query be
+// shared between a plain read and one nested inside a transaction (see
+// PurchaseUpgrade, which needs to read back upgrade levels including the
+// purchase it just inserted, before that transaction commits).
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func saveGameState(e execer, state *GameState) error {
+	if state.Realm == "" {
+		state.Realm = "main"
+	}
+	state.UpdatedAt = time.Now().UTC()
+
+	notifications, err := compressJSON(state.Notifications)
+	if err != nil {
+		return fmt.Errorf("failed to encode notifications: %w", err)
+	}
+
+	_, err = e.Exec(
+		`INSERT INTO game_states (player_id, realm, current_level, keystrokes, words,
+		     programs, ai_automations, story_progress, production_rate, notifications, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(player_id, realm) DO UPDATE SET
+		     current_level = excluded.current_level,
+		     keystrokes = excluded.keystrokes,
+		     words = excluded.words,
+		     programs = excluded.programs,
+		     ai_automations = excluded.ai_automations,
+		     story_progress = excluded.story_progress,
+		     production_rate = excluded.production_rate,
+		     notifications = excluded.notifications,
+		     updated_at = excluded.updated_at`,
+		state.PlayerID, state.Realm, state.CurrentLevel, state.Keystrokes, state.Words,
+		state.Programs, state.AIAutomations, state.StoryProgress,
+		state.ProductionRate, notifications, state.UpdatedAt,
+	)
+	return err
+}
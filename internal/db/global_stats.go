@@ -0,0 +1,28 @@
+package db
+
+import "fmt"
+
+// GetGlobalStats aggregates totals across every player. Players who have
+// never recorded lifetime stats still count towards TotalPlayers, since the
+// join is a LEFT JOIN against lifetime_stats.
+func (s *SQLiteDB) GetGlobalStats() (*GlobalStats, error) {
+	row := s.conn.QueryRow(
+		`SELECT COUNT(players.id),
+		        COALESCE(SUM(lifetime_stats.total_keystrokes), 0),
+		        COALESCE(SUM(lifetime_stats.total_words), 0),
+		        COALESCE(SUM(lifetime_stats.total_programs), 0),
+		        COALESCE(SUM(lifetime_stats.playtime_seconds), 0)
+		 FROM players
+		 LEFT JOIN lifetime_stats ON lifetime_stats.player_id = players.id`,
+	)
+
+	var stats GlobalStats
+	if err := row.Scan(
+		&stats.TotalPlayers, &stats.TotalKeystrokes, &stats.TotalWords,
+		&stats.TotalPrograms, &stats.TotalPlaytimeSeconds,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get global stats: %w", err)
+	}
+
+	return &stats, nil
+}
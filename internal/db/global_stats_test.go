@@ -0,0 +1,49 @@
+package db
+
+import "testing"
+
+func TestGetGlobalStatsAggregatesAcrossPlayers(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	if err := sdb.AddLifetimeStats("p1", LifetimeStats{TotalKeystrokes: 10, TotalWords: 2}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+	if err := sdb.AddLifetimeStats("p2", LifetimeStats{TotalKeystrokes: 5, TotalPrograms: 1}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+
+	stats, err := sdb.GetGlobalStats()
+	if err != nil {
+		t.Fatalf("GetGlobalStats() error = %v", err)
+	}
+	if stats.TotalPlayers != 2 {
+		t.Errorf("TotalPlayers = %d, want 2", stats.TotalPlayers)
+	}
+	if stats.TotalKeystrokes != 15 {
+		t.Errorf("TotalKeystrokes = %v, want 15", stats.TotalKeystrokes)
+	}
+	if stats.TotalWords != 2 || stats.TotalPrograms != 1 {
+		t.Errorf("TotalWords/TotalPrograms = %d/%d, want 2/1", stats.TotalWords, stats.TotalPrograms)
+	}
+}
+
+func TestGetGlobalStatsCountsPlayersWithNoLifetimeStats(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	stats, err := sdb.GetGlobalStats()
+	if err != nil {
+		t.Fatalf("GetGlobalStats() error = %v", err)
+	}
+	if stats.TotalPlayers != 1 {
+		t.Errorf("TotalPlayers = %d, want 1", stats.TotalPlayers)
+	}
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CreateGuild creates a new guild and adds its owner as the first member, in
+// a single transaction so a guild never exists without its owner.
+func (s *SQLiteDB) CreateGuild(guild *Guild) error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	guild.CreatedAt = time.Now().UTC()
+	_, err = tx.Exec(
+		`INSERT INTO guilds (id, name, owner_id, created_at) VALUES (?, ?, ?, ?)`,
+		guild.ID, guild.Name, guild.OwnerID, guild.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create guild %s: %w", guild.Name, err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO guild_members (guild_id, player_id, role, joined_at) VALUES (?, ?, ?, ?)`,
+		guild.ID, guild.OwnerID, GuildRoleOwner, guild.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add owner to guild %s: %w", guild.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit guild creation: %w", err)
+	}
+
+	return nil
+}
+
+// GetGuild looks up a guild by ID.
+func (s *SQLiteDB) GetGuild(id string) (*Guild, error) {
+	row := s.conn.QueryRow(`SELECT id, name, owner_id, created_at FROM guilds WHERE id = ?`, id)
+
+	var g Guild
+	if err := row.Scan(&g.ID, &g.Name, &g.OwnerID, &g.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("guild %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get guild %s: %w", id, err)
+	}
+
+	return &g, nil
+}
+
+// JoinGuild adds a player to a guild as a regular member.
+func (s *SQLiteDB) JoinGuild(guildID, playerID string) error {
+	_, err := s.conn.Exec(
+		`INSERT INTO guild_members (guild_id, player_id, role, joined_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(guild_id, player_id) DO NOTHING`,
+		guildID, playerID, GuildRoleMember, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to guild %s: %w", playerID, guildID, err)
+	}
+
+	return nil
+}
+
+// LeaveGuild removes a player from a guild.
+func (s *SQLiteDB) LeaveGuild(guildID, playerID string) error {
+	_, err := s.conn.Exec(
+		`DELETE FROM guild_members WHERE guild_id = ? AND player_id = ?`,
+		guildID, playerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove %s from guild %s: %w", playerID, guildID, err)
+	}
+
+	return nil
+}
+
+// transferOrDisbandOwnedGuilds hands off ownership of every guild the given
+// player owns to their longest-standing fellow member, or deletes the guild
+// entirely if it has no other members. It's called from within
+// DeletePlayer's transaction so a departing owner never leaves a guild with
+// a dangling owner_id.
+func transferOrDisbandOwnedGuilds(tx *sql.Tx, playerID string) error {
+	rows, err := tx.Query(`SELECT id FROM guilds WHERE owner_id = ?`, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to find guilds owned by %s: %w", playerID, err)
+	}
+	var guildIDs []string
+	for rows.Next() {
+		var guildID string
+		if err := rows.Scan(&guildID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan guild row: %w", err)
+		}
+		guildIDs = append(guildIDs, guildID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, guildID := range guildIDs {
+		var successorID string
+		err := tx.QueryRow(
+			`SELECT player_id FROM guild_members WHERE guild_id = ? AND player_id != ? ORDER BY joined_at LIMIT 1`,
+			guildID, playerID,
+		).Scan(&successorID)
+		if err == sql.ErrNoRows {
+			if _, err := tx.Exec(`DELETE FROM guild_members WHERE guild_id = ?`, guildID); err != nil {
+				return fmt.Errorf("failed to delete members of guild %s: %w", guildID, err)
+			}
+			if _, err := tx.Exec(`DELETE FROM guilds WHERE id = ?`, guildID); err != nil {
+				return fmt.Errorf("failed to delete guild %s: %w", guildID, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find a successor for guild %s: %w", guildID, err)
+		}
+
+		if _, err := tx.Exec(`UPDATE guilds SET owner_id = ? WHERE id = ?`, successorID, guildID); err != nil {
+			return fmt.Errorf("failed to transfer guild %s to %s: %w", guildID, successorID, err)
+		}
+		if _, err := tx.Exec(`UPDATE guild_members SET role = ? WHERE guild_id = ? AND player_id = ?`, GuildRoleOwner, guildID, successorID); err != nil {
+			return fmt.Errorf("failed to promote %s in guild %s: %w", successorID, guildID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetGuildMembers lists everyone in a guild, owner first.
+func (s *SQLiteDB) GetGuildMembers(guildID string) ([]*GuildMember, error) {
+	rows, err := s.conn.Query(
+		`SELECT gm.guild_id, gm.player_id, p.username, gm.role, gm.joined_at
+		 FROM guild_members gm
+		 JOIN players p ON p.id = gm.player_id
+		 WHERE gm.guild_id = ?
+		 ORDER BY gm.role = 'owner' DESC, gm.joined_at`,
+		guildID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members of guild %s: %w", guildID, err)
+	}
+	defer rows.Close()
+
+	var members []*GuildMember
+	for rows.Next() {
+		var m GuildMember
+		if err := rows.Scan(&m.GuildID, &m.PlayerID, &m.Username, &m.Role, &m.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan guild member row: %w", err)
+		}
+		members = append(members, &m)
+	}
+
+	return members, rows.Err()
+}
@@ -0,0 +1,59 @@
+package db
+
+import "testing"
+
+func TestCreateGuildAddsOwnerAsMember(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "alice", Username: "alice"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	guild := &Guild{ID: "g1", Name: "Typists Guild", OwnerID: "alice"}
+	if err := sdb.CreateGuild(guild); err != nil {
+		t.Fatalf("CreateGuild() error = %v", err)
+	}
+
+	members, err := sdb.GetGuildMembers("g1")
+	if err != nil {
+		t.Fatalf("GetGuildMembers() error = %v", err)
+	}
+	if len(members) != 1 || members[0].PlayerID != "alice" || members[0].Role != GuildRoleOwner {
+		t.Fatalf("GetGuildMembers() = %+v, want [alice owner]", members)
+	}
+}
+
+func TestJoinAndLeaveGuild(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for _, id := range []string{"alice", "bob"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+	if err := sdb.CreateGuild(&Guild{ID: "g1", Name: "Typists Guild", OwnerID: "alice"}); err != nil {
+		t.Fatalf("CreateGuild() error = %v", err)
+	}
+
+	if err := sdb.JoinGuild("g1", "bob"); err != nil {
+		t.Fatalf("JoinGuild() error = %v", err)
+	}
+	members, err := sdb.GetGuildMembers("g1")
+	if err != nil {
+		t.Fatalf("GetGuildMembers() error = %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("GetGuildMembers() = %d members, want 2", len(members))
+	}
+
+	if err := sdb.LeaveGuild("g1", "bob"); err != nil {
+		t.Fatalf("LeaveGuild() error = %v", err)
+	}
+	members, err = sdb.GetGuildMembers("g1")
+	if err != nil {
+		t.Fatalf("GetGuildMembers() error = %v", err)
+	}
+	if len(members) != 1 {
+		t.Fatalf("GetGuildMembers() = %d members, want 1", len(members))
+	}
+}
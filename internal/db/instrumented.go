@@ -0,0 +1,444 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// MethodStats are the running call metrics for one Database method.
+type MethodStats struct {
+	Calls         int64
+	Errors        int64
+	TotalDuration time.Duration
+}
+
+// InstrumentedDB wraps a Database, recording per-method call counts,
+// durations, and error rates without touching every call site. QueryMetrics
+// exposes the running totals so they can be exported via the metrics
+// endpoint.
+type InstrumentedDB struct {
+	db Database
+
+	mu    sync.Mutex
+	stats map[string]MethodStats
+}
+
+var _ Database = (*InstrumentedDB)(nil)
+
+// NewInstrumentedDB wraps db with per-method call metrics.
+func NewInstrumentedDB(db Database) *InstrumentedDB {
+	return &InstrumentedDB{db: db, stats: make(map[string]MethodStats)}
+}
+
+// QueryMetrics returns a snapshot of recorded call metrics, keyed by method
+// name.
+func (i *InstrumentedDB) QueryMetrics() map[string]MethodStats {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	snapshot := make(map[string]MethodStats, len(i.stats))
+	for name, s := range i.stats {
+		snapshot[name] = s
+	}
+	return snapshot
+}
+
+func (i *InstrumentedDB) observe(method string, start time.Time, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	s := i.stats[method]
+	s.Calls++
+	s.TotalDuration += time.Since(start)
+	if err != nil {
+		s.Errors++
+	}
+	i.stats[method] = s
+}
+
+func (i *InstrumentedDB) GetPlayer(id string) (*Player, error) {
+	start := time.Now()
+	p, err := i.db.GetPlayer(id)
+	i.observe("GetPlayer", start, err)
+	return p, err
+}
+
+func (i *InstrumentedDB) GetPlayerByUsername(username string) (*Player, error) {
+	start := time.Now()
+	p, err := i.db.GetPlayerByUsername(username)
+	i.observe("GetPlayerByUsername", start, err)
+	return p, err
+}
+
+func (i *InstrumentedDB) GetPlayerByPublicKey(publicKey string) (*Player, error) {
+	start := time.Now()
+	p, err := i.db.GetPlayerByPublicKey(publicKey)
+	i.observe("GetPlayerByPublicKey", start, err)
+	return p, err
+}
+
+func (i *InstrumentedDB) SavePlayer(player *Player) error {
+	start := time.Now()
+	err := i.db.SavePlayer(player)
+	i.observe("SavePlayer", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) CreateOrGetPlayer(id, username, publicKey, realm string) (*Player, error) {
+	start := time.Now()
+	p, err := i.db.CreateOrGetPlayer(id, username, publicKey, realm)
+	i.observe("CreateOrGetPlayer", start, err)
+	return p, err
+}
+
+func (i *InstrumentedDB) CreateOrGetPlayerByKey(id, username, publicKey, realm string) (*Player, error) {
+	start := time.Now()
+	p, err := i.db.CreateOrGetPlayerByKey(id, username, publicKey, realm)
+	i.observe("CreateOrGetPlayerByKey", start, err)
+	return p, err
+}
+
+func (i *InstrumentedDB) DeletePlayer(id string) error {
+	start := time.Now()
+	err := i.db.DeletePlayer(id)
+	i.observe("DeletePlayer", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) SetPlayerBanned(id string, banned bool) error {
+	start := time.Now()
+	err := i.db.SetPlayerBanned(id, banned)
+	i.observe("SetPlayerBanned", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) SetPlayerSuspended(id string, suspended bool) error {
+	start := time.Now()
+	err := i.db.SetPlayerSuspended(id, suspended)
+	i.observe("SetPlayerSuspended", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) RenamePlayer(id, username string) error {
+	start := time.Now()
+	err := i.db.RenamePlayer(id, username)
+	i.observe("RenamePlayer", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) RotatePlayerKey(id, publicKey string) error {
+	start := time.Now()
+	err := i.db.RotatePlayerKey(id, publicKey)
+	i.observe("RotatePlayerKey", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) UpdateLastActive(id string) error {
+	start := time.Now()
+	err := i.db.UpdateLastActive(id)
+	i.observe("UpdateLastActive", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) StartSession(playerID, sourceIP string) (int64, error) {
+	start := time.Now()
+	id, err := i.db.StartSession(playerID, sourceIP)
+	i.observe("StartSession", start, err)
+	return id, err
+}
+
+func (i *InstrumentedDB) EndSession(id int64, bytesIn, bytesOut int64) error {
+	start := time.Now()
+	err := i.db.EndSession(id, bytesIn, bytesOut)
+	i.observe("EndSession", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetLastSession(playerID string) (*SessionRecord, error) {
+	start := time.Now()
+	rec, err := i.db.GetLastSession(playerID)
+	i.observe("GetLastSession", start, err)
+	return rec, err
+}
+
+func (i *InstrumentedDB) Ping(ctx context.Context) error {
+	start := time.Now()
+	err := i.db.Ping(ctx)
+	i.observe("Ping", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) Stats() sql.DBStats {
+	start := time.Now()
+	s := i.db.Stats()
+	i.observe("Stats", start, nil)
+	return s
+}
+
+func (i *InstrumentedDB) SearchPlayers(query string, limit int) ([]*Player, error) {
+	start := time.Now()
+	players, err := i.db.SearchPlayers(query, limit)
+	i.observe("SearchPlayers", start, err)
+	return players, err
+}
+
+func (i *InstrumentedDB) GetGameState(playerID, realm string) (*GameState, error) {
+	start := time.Now()
+	gs, err := i.db.GetGameState(playerID, realm)
+	i.observe("GetGameState", start, err)
+	return gs, err
+}
+
+func (i *InstrumentedDB) SaveGameState(state *GameState) error {
+	start := time.Now()
+	err := i.db.SaveGameState(state)
+	i.observe("SaveGameState", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) PurchaseUpgrade(playerID, upgradeType string, level int, state *GameState) error {
+	start := time.Now()
+	err := i.db.PurchaseUpgrade(playerID, upgradeType, level, state)
+	i.observe("PurchaseUpgrade", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetUpgradeLevels(playerID string) (map[string]int, error) {
+	start := time.Now()
+	levels, err := i.db.GetUpgradeLevels(playerID)
+	i.observe("GetUpgradeLevels", start, err)
+	return levels, err
+}
+
+func (i *InstrumentedDB) GetLifetimeStats(playerID string) (*LifetimeStats, error) {
+	start := time.Now()
+	stats, err := i.db.GetLifetimeStats(playerID)
+	i.observe("GetLifetimeStats", start, err)
+	return stats, err
+}
+
+func (i *InstrumentedDB) AddLifetimeStats(playerID string, delta LifetimeStats) error {
+	start := time.Now()
+	err := i.db.AddLifetimeStats(playerID, delta)
+	i.observe("AddLifetimeStats", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) SendFriendRequest(fromID, toID string) error {
+	start := time.Now()
+	err := i.db.SendFriendRequest(fromID, toID)
+	i.observe("SendFriendRequest", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) AcceptFriendRequest(playerID, requesterID string) error {
+	start := time.Now()
+	err := i.db.AcceptFriendRequest(playerID, requesterID)
+	i.observe("AcceptFriendRequest", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) RemoveFriend(playerID, friendID string) error {
+	start := time.Now()
+	err := i.db.RemoveFriend(playerID, friendID)
+	i.observe("RemoveFriend", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetFriends(playerID string) ([]*Friend, error) {
+	start := time.Now()
+	friends, err := i.db.GetFriends(playerID)
+	i.observe("GetFriends", start, err)
+	return friends, err
+}
+
+func (i *InstrumentedDB) CreateGuild(guild *Guild) error {
+	start := time.Now()
+	err := i.db.CreateGuild(guild)
+	i.observe("CreateGuild", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetGuild(id string) (*Guild, error) {
+	start := time.Now()
+	guild, err := i.db.GetGuild(id)
+	i.observe("GetGuild", start, err)
+	return guild, err
+}
+
+func (i *InstrumentedDB) JoinGuild(guildID, playerID string) error {
+	start := time.Now()
+	err := i.db.JoinGuild(guildID, playerID)
+	i.observe("JoinGuild", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) LeaveGuild(guildID, playerID string) error {
+	start := time.Now()
+	err := i.db.LeaveGuild(guildID, playerID)
+	i.observe("LeaveGuild", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetGuildMembers(guildID string) ([]*GuildMember, error) {
+	start := time.Now()
+	members, err := i.db.GetGuildMembers(guildID)
+	i.observe("GetGuildMembers", start, err)
+	return members, err
+}
+
+func (i *InstrumentedDB) GetLeaderboard(realm string, sort LeaderboardSort, minLevel, limit, offset int) ([]*LeaderboardEntry, error) {
+	start := time.Now()
+	entries, err := i.db.GetLeaderboard(realm, sort, minLevel, limit, offset)
+	i.observe("GetLeaderboard", start, err)
+	return entries, err
+}
+
+func (i *InstrumentedDB) CountLeaderboard(realm string, minLevel int) (int, error) {
+	start := time.Now()
+	count, err := i.db.CountLeaderboard(realm, minLevel)
+	i.observe("CountLeaderboard", start, err)
+	return count, err
+}
+
+func (i *InstrumentedDB) GetLeaderboardAround(realm string, sort LeaderboardSort, minLevel int, playerID string, rangeN int) ([]*LeaderboardEntry, error) {
+	start := time.Now()
+	entries, err := i.db.GetLeaderboardAround(realm, sort, minLevel, playerID, rangeN)
+	i.observe("GetLeaderboardAround", start, err)
+	return entries, err
+}
+
+func (i *InstrumentedDB) UpdateLeaderboard(entry *LeaderboardEntry) error {
+	start := time.Now()
+	err := i.db.UpdateLeaderboard(entry)
+	i.observe("UpdateLeaderboard", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetGlobalStats() (*GlobalStats, error) {
+	start := time.Now()
+	stats, err := i.db.GetGlobalStats()
+	i.observe("GetGlobalStats", start, err)
+	return stats, err
+}
+
+func (i *InstrumentedDB) AddConnectionBan(value, kind, reason string) error {
+	start := time.Now()
+	err := i.db.AddConnectionBan(value, kind, reason)
+	i.observe("AddConnectionBan", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) RemoveConnectionBan(value string) error {
+	start := time.Now()
+	err := i.db.RemoveConnectionBan(value)
+	i.observe("RemoveConnectionBan", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) ListConnectionBans() ([]*ConnectionBan, error) {
+	start := time.Now()
+	bans, err := i.db.ListConnectionBans()
+	i.observe("ListConnectionBans", start, err)
+	return bans, err
+}
+
+func (i *InstrumentedDB) RecordAuthAttempt(username, fingerprint, sourceIP string, success bool, reason string) error {
+	start := time.Now()
+	err := i.db.RecordAuthAttempt(username, fingerprint, sourceIP, success, reason)
+	i.observe("RecordAuthAttempt", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetAuthAttempts(username string, limit int) ([]*AuthAttempt, error) {
+	start := time.Now()
+	attempts, err := i.db.GetAuthAttempts(username, limit)
+	i.observe("GetAuthAttempts", start, err)
+	return attempts, err
+}
+
+func (i *InstrumentedDB) GetReplicationLog(afterID int64, limit int) ([]*ReplicationEvent, error) {
+	start := time.Now()
+	events, err := i.db.GetReplicationLog(afterID, limit)
+	i.observe("GetReplicationLog", start, err)
+	return events, err
+}
+
+func (i *InstrumentedDB) PruneReplicationLog(throughID int64) error {
+	start := time.Now()
+	err := i.db.PruneReplicationLog(throughID)
+	i.observe("PruneReplicationLog", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) AddNotification(playerID, realm, message, severity string) error {
+	start := time.Now()
+	err := i.db.AddNotification(playerID, realm, message, severity)
+	i.observe("AddNotification", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) GetNotificationsSince(playerID string, since time.Time, limit int) ([]*Notification, error) {
+	start := time.Now()
+	notifications, err := i.db.GetNotificationsSince(playerID, since, limit)
+	i.observe("GetNotificationsSince", start, err)
+	return notifications, err
+}
+
+func (i *InstrumentedDB) IssueAPIToken(playerID string) (string, error) {
+	start := time.Now()
+	token, err := i.db.IssueAPIToken(playerID)
+	i.observe("IssueAPIToken", start, err)
+	return token, err
+}
+
+func (i *InstrumentedDB) RotateAPIToken(playerID string) (string, error) {
+	start := time.Now()
+	token, err := i.db.RotateAPIToken(playerID)
+	i.observe("RotateAPIToken", start, err)
+	return token, err
+}
+
+func (i *InstrumentedDB) RevokeAPIToken(token string) error {
+	start := time.Now()
+	err := i.db.RevokeAPIToken(token)
+	i.observe("RevokeAPIToken", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) AuthenticateAPIToken(token string) (*Player, error) {
+	start := time.Now()
+	player, err := i.db.AuthenticateAPIToken(token)
+	i.observe("AuthenticateAPIToken", start, err)
+	return player, err
+}
+
+func (i *InstrumentedDB) IssuePairingCode() (string, error) {
+	start := time.Now()
+	code, err := i.db.IssuePairingCode()
+	i.observe("IssuePairingCode", start, err)
+	return code, err
+}
+
+func (i *InstrumentedDB) ClaimPairingCode(code, playerID string) error {
+	start := time.Now()
+	err := i.db.ClaimPairingCode(code, playerID)
+	i.observe("ClaimPairingCode", start, err)
+	return err
+}
+
+func (i *InstrumentedDB) ResolvePairingCode(code string) (*Player, error) {
+	start := time.Now()
+	player, err := i.db.ResolvePairingCode(code)
+	i.observe("ResolvePairingCode", start, err)
+	return player, err
+}
+
+func (i *InstrumentedDB) Close() error {
+	start := time.Now()
+	err := i.db.Close()
+	i.observe("Close", start, err)
+	return err
+}
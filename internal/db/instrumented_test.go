@@ -0,0 +1,30 @@
+package db
+
+import "testing"
+
+func TestInstrumentedDBRecordsCallsAndErrors(t *testing.T) {
+	sdb := newTestDB(t)
+	idb := NewInstrumentedDB(sdb)
+
+	if err := idb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if _, err := idb.GetPlayer("p1"); err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if _, err := idb.GetPlayer("missing"); err == nil {
+		t.Fatal("GetPlayer(missing) error = nil, want ErrNotFound")
+	}
+
+	metrics := idb.QueryMetrics()
+
+	save := metrics["SavePlayer"]
+	if save.Calls != 1 || save.Errors != 0 {
+		t.Errorf("SavePlayer metrics = %+v, want 1 call, 0 errors", save)
+	}
+
+	get := metrics["GetPlayer"]
+	if get.Calls != 2 || get.Errors != 1 {
+		t.Errorf("GetPlayer metrics = %+v, want 2 calls, 1 error", get)
+	}
+}
@@ -0,0 +1,157 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetLeaderboard returns a page of players in realm ranked by sort, highest
+// first. If minLevel is positive, players whose current game level is below
+// it are excluded. offset skips that many leading rows, letting callers
+// page through results with (limit, offset) pairs or advance a cursor by
+// tracking the last offset they consumed.
+func (s *SQLiteDB) GetLeaderboard(realm string, sort LeaderboardSort, minLevel, limit, offset int) ([]*LeaderboardEntry, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	// sort.column() is restricted to a fixed whitelist, so it's safe to
+	// interpolate directly into the query.
+	query := fmt.Sprintf(
+		`SELECT l.player_id, l.realm, l.username, l.keystrokes, l.words, l.programs, l.updated_at
+		 FROM leaderboard_entries l
+		 JOIN players p ON p.id = l.player_id
+		 LEFT JOIN game_states g ON g.player_id = l.player_id AND g.realm = l.realm
+		 WHERE l.realm = ? AND p.banned = 0 AND p.suspended = 0 AND COALESCE(g.current_level, 1) >= ?
+		 ORDER BY l.%s DESC, l.player_id
+		 LIMIT ? OFFSET ?`,
+		sort.column(),
+	)
+
+	rows, err := s.conn.Query(query, realm, minLevel, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard for realm %s: %w", realm, err)
+	}
+	defer rows.Close()
+
+	var entries []*LeaderboardEntry
+	for rows.Next() {
+		var e LeaderboardEntry
+		if err := rows.Scan(&e.PlayerID, &e.Realm, &e.Username, &e.Keystrokes, &e.Words, &e.Programs, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard row: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// CountLeaderboard returns how many (non-banned, non-suspended) players with
+// at least minLevel have a leaderboard entry in realm, so callers can page
+// through GetLeaderboard without re-querying for a total on every page.
+func (s *SQLiteDB) CountLeaderboard(realm string, minLevel int) (int, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	var count int
+	row := s.conn.QueryRow(
+		`SELECT COUNT(*)
+		 FROM leaderboard_entries l
+		 JOIN players p ON p.id = l.player_id
+		 LEFT JOIN game_states g ON g.player_id = l.player_id AND g.realm = l.realm
+		 WHERE l.realm = ? AND p.banned = 0 AND p.suspended = 0 AND COALESCE(g.current_level, 1) >= ?`,
+		realm, minLevel,
+	)
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard for realm %s: %w", realm, err)
+	}
+	return count, nil
+}
+
+// GetLeaderboardAround returns playerID's rank plus up to rangeN neighbors
+// on either side, ranked the same way GetLeaderboard would rank them. It
+// uses a window function to compute every player's rank in a single query
+// rather than scanning pages of GetLeaderboard until playerID turns up,
+// which would degrade badly for a player ranked far from the top. It
+// returns ErrNotFound if playerID has no leaderboard entry in realm.
+func (s *SQLiteDB) GetLeaderboardAround(realm string, sort LeaderboardSort, minLevel int, playerID string, rangeN int) ([]*LeaderboardEntry, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	// sort.column() is restricted to a fixed whitelist, so it's safe to
+	// interpolate directly into the query.
+	query := fmt.Sprintf(
+		`WITH ranked AS (
+		     SELECT l.player_id, l.realm, l.username, l.keystrokes, l.words, l.programs, l.updated_at,
+		            ROW_NUMBER() OVER (ORDER BY l.%s DESC, l.player_id) AS rnk
+		     FROM leaderboard_entries l
+		     JOIN players p ON p.id = l.player_id
+		     LEFT JOIN game_states g ON g.player_id = l.player_id AND g.realm = l.realm
+		     WHERE l.realm = ? AND p.banned = 0 AND p.suspended = 0 AND COALESCE(g.current_level, 1) >= ?
+		 )
+		 SELECT player_id, realm, username, keystrokes, words, programs, updated_at, rnk
+		 FROM ranked
+		 WHERE rnk BETWEEN (SELECT rnk FROM ranked WHERE player_id = ?) - ? AND (SELECT rnk FROM ranked WHERE player_id = ?) + ?
+		 ORDER BY rnk`,
+		sort.column(),
+	)
+
+	rows, err := s.conn.Query(query, realm, minLevel, playerID, rangeN, playerID, rangeN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard around %s in realm %s: %w", playerID, realm, err)
+	}
+	defer rows.Close()
+
+	var entries []*LeaderboardEntry
+	for rows.Next() {
+		var e LeaderboardEntry
+		if err := rows.Scan(&e.PlayerID, &e.Realm, &e.Username, &e.Keystrokes, &e.Words, &e.Programs, &e.UpdatedAt, &e.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard row: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if e.PlayerID == playerID {
+			return entries, nil
+		}
+	}
+	return nil, fmt.Errorf("leaderboard entry for %s in realm %s: %w", playerID, realm, ErrNotFound)
+}
+
+// UpdateLeaderboard upserts a player's leaderboard standing within
+// entry.Realm.
+func (s *SQLiteDB) UpdateLeaderboard(entry *LeaderboardEntry) error {
+	if err := updateLeaderboard(s.conn, entry); err != nil {
+		return fmt.Errorf("failed to update leaderboard for %s/%s: %w", entry.Realm, entry.PlayerID, err)
+	}
+	return nil
+}
+
+// updateLeaderboard is shared between a plain UpdateLeaderboard and one
+// nested inside another table's transaction (see PurchaseUpgrade), using the
+// execer interface from game_state.go.
+func updateLeaderboard(e execer, entry *LeaderboardEntry) error {
+	if entry.Realm == "" {
+		entry.Realm = "main"
+	}
+	entry.UpdatedAt = time.Now().UTC()
+
+	_, err := e.Exec(
+		`INSERT INTO leaderboard_entries (player_id, realm, username, keystrokes, words, programs, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(player_id, realm) DO UPDATE SET
+		     username = excluded.username,
+		     keystrokes = excluded.keystrokes,
+		     words = excluded.words,
+		     programs = excluded.programs,
+		     updated_at = excluded.updated_at`,
+		entry.PlayerID, entry.Realm, entry.Username, entry.Keystrokes, entry.Words, entry.Programs, entry.UpdatedAt,
+	)
+	return err
+}
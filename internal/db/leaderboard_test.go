@@ -0,0 +1,125 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetLeaderboardSortByWords(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "alice"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SavePlayer(&Player{ID: "p2", Username: "bob"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Username: "alice", Keystrokes: 10, Words: 500}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p2", Username: "bob", Keystrokes: 20, Words: 100}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+
+	entries, err := sdb.GetLeaderboard("", SortByWords, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].PlayerID != "p1" {
+		t.Fatalf("GetLeaderboard(SortByWords) top = %+v, want p1 first", entries)
+	}
+}
+
+func TestGetLeaderboardOffset(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for i, id := range []string{"p1", "p2", "p3"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(30 - i*10)}); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+	}
+
+	page, err := sdb.GetLeaderboard("", SortByKeystrokes, 0, 1, 1)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(page) != 1 || page[0].PlayerID != "p2" {
+		t.Fatalf("GetLeaderboard(limit=1, offset=1) = %+v, want [p2]", page)
+	}
+}
+
+func TestGetLeaderboardMinLevel(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for i, id := range []string{"p1", "p2"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(10 - i)}); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+		level := 5
+		if i == 1 {
+			level = 1
+		}
+		state := &GameState{PlayerID: id, CurrentLevel: level, UpdatedAt: time.Now().UTC()}
+		if err := sdb.SaveGameState(state); err != nil {
+			t.Fatalf("SaveGameState(%s) error = %v", id, err)
+		}
+	}
+
+	entries, err := sdb.GetLeaderboard("", SortByKeystrokes, 3, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].PlayerID != "p1" {
+		t.Fatalf("GetLeaderboard(minLevel=3) = %+v, want [p1]", entries)
+	}
+
+	count, err := sdb.CountLeaderboard("", 3)
+	if err != nil {
+		t.Fatalf("CountLeaderboard() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountLeaderboard(minLevel=3) = %d, want 1", count)
+	}
+}
+
+func TestGetLeaderboardAround(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for i, id := range []string{"p1", "p2", "p3", "p4", "p5"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+		if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: id, Username: id, Keystrokes: float64(50 - i*10)}); err != nil {
+			t.Fatalf("UpdateLeaderboard(%s) error = %v", id, err)
+		}
+	}
+
+	entries, err := sdb.GetLeaderboardAround("", SortByKeystrokes, 0, "p3", 1)
+	if err != nil {
+		t.Fatalf("GetLeaderboardAround() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("GetLeaderboardAround() = %+v, want 3 entries", entries)
+	}
+	if entries[0].PlayerID != "p2" || entries[1].PlayerID != "p3" || entries[2].PlayerID != "p4" {
+		t.Errorf("GetLeaderboardAround() = %+v, want [p2 p3 p4]", entries)
+	}
+	if entries[1].Rank != 3 {
+		t.Errorf("p3 Rank = %d, want 3", entries[1].Rank)
+	}
+}
+
+func TestGetLeaderboardAroundNotFound(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if _, err := sdb.GetLeaderboardAround("", SortByKeystrokes, 0, "missing", 5); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetLeaderboardAround() error = %v, want ErrNotFound", err)
+	}
+}
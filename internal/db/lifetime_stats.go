@@ -0,0 +1,57 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GetLifetimeStats returns a player's cumulative totals, or a zero-value
+// record if they have never had any recorded.
+func (s *SQLiteDB) GetLifetimeStats(playerID string) (*LifetimeStats, error) {
+	row := s.conn.QueryRow(
+		`SELECT player_id, total_keystrokes, total_words, total_programs,
+		        playtime_seconds, sessions_played, updated_at
+		 FROM lifetime_stats WHERE player_id = ?`,
+		playerID,
+	)
+
+	var stats LifetimeStats
+	err := row.Scan(
+		&stats.PlayerID, &stats.TotalKeystrokes, &stats.TotalWords,
+		&stats.TotalPrograms, &stats.PlaytimeSeconds, &stats.SessionsPlayed,
+		&stats.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return &LifetimeStats{PlayerID: playerID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lifetime stats for %s: %w", playerID, err)
+	}
+
+	return &stats, nil
+}
+
+// AddLifetimeStats increments a player's cumulative totals by delta. Every
+// field in delta is treated as an increment, not a replacement.
+func (s *SQLiteDB) AddLifetimeStats(playerID string, delta LifetimeStats) error {
+	_, err := s.conn.Exec(
+		`INSERT INTO lifetime_stats (player_id, total_keystrokes, total_words,
+		     total_programs, playtime_seconds, sessions_played, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(player_id) DO UPDATE SET
+		     total_keystrokes = total_keystrokes + excluded.total_keystrokes,
+		     total_words = total_words + excluded.total_words,
+		     total_programs = total_programs + excluded.total_programs,
+		     playtime_seconds = playtime_seconds + excluded.playtime_seconds,
+		     sessions_played = sessions_played + excluded.sessions_played,
+		     updated_at = excluded.updated_at`,
+		playerID, delta.TotalKeystrokes, delta.TotalWords, delta.TotalPrograms,
+		delta.PlaytimeSeconds, delta.SessionsPlayed, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add lifetime stats for %s: %w", playerID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import "testing"
+
+func TestAddLifetimeStatsAccumulates(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.AddLifetimeStats("p1", LifetimeStats{TotalKeystrokes: 10, SessionsPlayed: 1}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+	if err := sdb.AddLifetimeStats("p1", LifetimeStats{TotalKeystrokes: 5, SessionsPlayed: 1}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+
+	stats, err := sdb.GetLifetimeStats("p1")
+	if err != nil {
+		t.Fatalf("GetLifetimeStats() error = %v", err)
+	}
+	if stats.TotalKeystrokes != 15 || stats.SessionsPlayed != 2 {
+		t.Errorf("GetLifetimeStats() = %+v, want keystrokes=15 sessions=2", stats)
+	}
+}
+
+func TestGetLifetimeStatsUnknownPlayer(t *testing.T) {
+	sdb := newTestDB(t)
+
+	stats, err := sdb.GetLifetimeStats("ghost")
+	if err != nil {
+		t.Fatalf("GetLifetimeStats() error = %v", err)
+	}
+	if stats.TotalKeystrokes != 0 {
+		t.Errorf("GetLifetimeStats() = %+v, want zero value", stats)
+	}
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration pairs an up step with its down step, identified by a shared base
+// name (the numeric prefix plus description, e.g. "0001_init").
+type migration struct {
+	name string
+	up   string
+	down string
+}
+
+// loadMigrations reads every embedded migration into name order, requiring
+// each to have both an up and a down step so a rollback is always possible.
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byName := make(map[string]*migration)
+	var names []string
+	for _, entry := range entries {
+		fileName := entry.Name()
+
+		var name, step string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			name, step = strings.TrimSuffix(fileName, ".up.sql"), "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			name, step = strings.TrimSuffix(fileName, ".down.sql"), "down"
+		default:
+			return nil, fmt.Errorf("migration file %s must end in .up.sql or .down.sql", fileName)
+		}
+
+		contents, err := migrationFiles.ReadFile("migrations/" + fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		m, ok := byName[name]
+		if !ok {
+			m = &migration{name: name}
+			byName[name] = m
+			names = append(names, name)
+		}
+		if step == "up" {
+			m.up = string(contents)
+		} else {
+			m.down = string(contents)
+		}
+	}
+	sort.Strings(names)
+
+	migrations := make([]migration, len(names))
+	for i, name := range names {
+		m := byName[name]
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %s is missing an up step", name)
+		}
+		if m.down == "" {
+			return nil, fmt.Errorf("migration %s is missing a down step", name)
+		}
+		migrations[i] = *m
+	}
+
+	return migrations, nil
+}
+
+// applyMigrations brings the schema to target, the base name of a migration
+// (e.g. "0003_sessions"), or the latest migration if target is "". Pending
+// migrations up to and including target are applied in order; migrations
+// already applied beyond target are rolled back with their down step, in
+// reverse order, so an operator can undo a bad schema change without
+// restoring a full backup.
+func applyMigrations(conn *sql.DB, target string) error {
+	if _, err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		name       TEXT PRIMARY KEY,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	if target != "" {
+		known := false
+		for _, m := range migrations {
+			if m.name == target {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown migration target %q", target)
+		}
+	}
+
+	for _, m := range migrations {
+		if target != "" && m.name > target {
+			continue
+		}
+
+		applied, err := migrationApplied(conn, m.name)
+		if err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+
+		if _, err := conn.Exec(m.up); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+		}
+		if _, err := conn.Exec(`INSERT INTO schema_migrations (name) VALUES (?)`, m.name); err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", m.name, err)
+		}
+	}
+
+	if target == "" {
+		return nil
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.name <= target {
+			continue
+		}
+
+		applied, err := migrationApplied(conn, m.name)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			continue
+		}
+
+		if _, err := conn.Exec(m.down); err != nil {
+			return fmt.Errorf("failed to roll back migration %s: %w", m.name, err)
+		}
+		if _, err := conn.Exec(`DELETE FROM schema_migrations WHERE name = ?`, m.name); err != nil {
+			return fmt.Errorf("failed to unrecord migration %s: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func migrationApplied(conn *sql.DB, name string) (bool, error) {
+	var applied bool
+	row := conn.QueryRow(`SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE name = ?)`, name)
+	if err := row.Scan(&applied); err != nil {
+		return false, fmt.Errorf("failed to check migration %s: %w", name, err)
+	}
+	return applied, nil
+}
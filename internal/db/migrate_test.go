@@ -0,0 +1,47 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSQLiteDBMigrateTargetRollsBackLaterMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	sdb, err := NewSQLiteDB(path, DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	sdb.Close()
+
+	opts := DefaultOptions()
+	opts.MigrateTarget = "0003_sessions"
+	sdb, err = NewSQLiteDB(path, opts)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() with MigrateTarget error = %v", err)
+	}
+	defer sdb.Close()
+
+	if _, err := sdb.conn.Exec(`SELECT words, programs FROM leaderboard_entries LIMIT 1`); err == nil {
+		t.Error("leaderboard_entries.words/programs still exist, want rolled back by migration 0004")
+	}
+
+	var applied bool
+	row := sdb.conn.QueryRow(`SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE name = '0003_sessions')`)
+	if err := row.Scan(&applied); err != nil {
+		t.Fatalf("failed to check schema_migrations: %v", err)
+	}
+	if !applied {
+		t.Error("0003_sessions should remain applied after rolling back to it")
+	}
+}
+
+func TestNewSQLiteDBMigrateTargetUnknown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	opts := DefaultOptions()
+	opts.MigrateTarget = "0099_does_not_exist"
+	if _, err := NewSQLiteDB(path, opts); err == nil {
+		t.Error("NewSQLiteDB() error = nil, want error for unknown migration target")
+	}
+}
@@ -0,0 +1,195 @@
+package db
+
+import "time"
+
+// Player represents an authenticated user of the game.
+type Player struct {
+	ID         string
+	Username   string
+	PublicKey  string
+	Realm      string
+	Banned     bool
+	Suspended  bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LastActive time.Time
+}
+
+// GameState is a player's persisted progress within a single realm. The same
+// player can hold independent GameStates in different realms.
+type GameState struct {
+	PlayerID       string    `json:"player_id"`
+	Realm          string    `json:"realm"`
+	CurrentLevel   int       `json:"current_level"`
+	Keystrokes     float64   `json:"keystrokes"`
+	Words          int       `json:"words"`
+	Programs       int       `json:"programs"`
+	AIAutomations  int       `json:"ai_automations"`
+	StoryProgress  int       `json:"story_progress"`
+	ProductionRate float64   `json:"production_rate"`
+	Notifications  []string  `json:"notifications"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// UpgradeLevels is the current level of every upgrade the player owns,
+	// keyed by upgrade type. It's populated by GetGameState from the
+	// player_upgrades ledger rather than stored on this row, so it's left
+	// nil (not persisted) by SaveGameState.
+	UpgradeLevels map[string]int `json:"upgrade_levels,omitempty"`
+}
+
+// LifetimeStats are cumulative totals for a player that survive resets and
+// prestige, unlike GameState which reflects current progress.
+type LifetimeStats struct {
+	PlayerID        string
+	TotalKeystrokes float64
+	TotalWords      int
+	TotalPrograms   int
+	PlaytimeSeconds int64
+	SessionsPlayed  int
+	UpdatedAt       time.Time
+}
+
+// FriendStatus is the state of a friendship between two players.
+type FriendStatus string
+
+const (
+	FriendStatusPending  FriendStatus = "pending"
+	FriendStatusAccepted FriendStatus = "accepted"
+)
+
+// Friend is another player as seen from playerID's friend list.
+type Friend struct {
+	PlayerID  string
+	Username  string
+	Status    FriendStatus
+	CreatedAt time.Time
+}
+
+// GuildRole is a member's rank within a guild.
+type GuildRole string
+
+const (
+	GuildRoleOwner  GuildRole = "owner"
+	GuildRoleMember GuildRole = "member"
+)
+
+// Guild is a player-created group.
+type Guild struct {
+	ID        string
+	Name      string
+	OwnerID   string
+	CreatedAt time.Time
+}
+
+// GuildMember is a player's membership in a guild.
+type GuildMember struct {
+	GuildID  string
+	PlayerID string
+	Username string
+	Role     GuildRole
+	JoinedAt time.Time
+}
+
+// SessionRecord is one SSH connection's lifetime, used for "last seen from"
+// info and abuse investigation.
+type SessionRecord struct {
+	ID        int64
+	PlayerID  string
+	SourceIP  string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	BytesIn   int64
+	BytesOut  int64
+}
+
+// LeaderboardEntry is a single row of the competitive leaderboard within a
+// single realm.
+type LeaderboardEntry struct {
+	PlayerID   string    `json:"player_id"`
+	Realm      string    `json:"realm"`
+	Username   string    `json:"username"`
+	Keystrokes float64   `json:"keystrokes"`
+	Words      int       `json:"words"`
+	Programs   int       `json:"programs"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Rank is only populated by queries that compute a player's standing,
+	// such as GetLeaderboardAround; it is zero (and omitted) elsewhere.
+	Rank int `json:"rank,omitempty"`
+}
+
+// LeaderboardSort selects which stat the leaderboard is ranked by.
+type LeaderboardSort string
+
+const (
+	SortByKeystrokes LeaderboardSort = "keystrokes"
+	SortByWords      LeaderboardSort = "words"
+	SortByPrograms   LeaderboardSort = "programs"
+)
+
+// ReplicationEvent is one row of the replication_log changelog, recording a
+// single insert/update/delete against a replicated table. An external
+// shipper can tail these by ID to replicate the database to object storage
+// without diffing full table snapshots.
+type ReplicationEvent struct {
+	ID         int64
+	TableName  string
+	Operation  string
+	RowID      string
+	RecordedAt time.Time
+}
+
+// Notification is a single persisted event for a player (e.g. a level-up
+// milestone), so a client that was offline when it happened can catch up by
+// polling for everything since its last-seen timestamp.
+type Notification struct {
+	ID        int64     `json:"id"`
+	PlayerID  string    `json:"player_id"`
+	Realm     string    `json:"realm"`
+	Message   string    `json:"message"`
+	Severity  string    `json:"severity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ConnectionBan blocks new SSH connections matching Value, either a CIDR
+// range (Kind "cidr") or an exact public key fingerprint (Kind "key").
+type ConnectionBan struct {
+	Value     string    `json:"value"`
+	Kind      string    `json:"kind"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuthAttempt records the outcome of one SSH public key authentication
+// attempt, for operators investigating brute-force attempts or
+// impersonation.
+type AuthAttempt struct {
+	ID          int64     `json:"id"`
+	Username    string    `json:"username"`
+	Fingerprint string    `json:"fingerprint"`
+	SourceIP    string    `json:"source_ip"`
+	Success     bool      `json:"success"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GlobalStats are totals across every player, for a server-wide "here's
+// how much progress everyone has made together" view.
+type GlobalStats struct {
+	TotalPlayers         int     `json:"total_players"`
+	TotalKeystrokes      float64 `json:"total_keystrokes"`
+	TotalWords           int     `json:"total_words"`
+	TotalPrograms        int     `json:"total_programs"`
+	TotalPlaytimeSeconds int64   `json:"total_playtime_seconds"`
+}
+
+// column returns the leaderboard_entries column this sort ranks by,
+// defaulting to keystrokes for an unrecognized value.
+func (s LeaderboardSort) column() string {
+	switch s {
+	case SortByWords:
+		return "words"
+	case SortByPrograms:
+		return "programs"
+	default:
+		return "keystrokes"
+	}
+}
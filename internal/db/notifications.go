@@ -0,0 +1,62 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// Notification severities, used by clients to decide how to draw attention
+// to an entry (e.g. coloring it in the game view).
+const (
+	NotificationInfo    = "info"
+	NotificationSuccess = "success"
+	NotificationWarning = "warning"
+)
+
+// AddNotification persists a single event for playerID at severity, so a
+// client that was offline when it happened can catch up later via
+// GetNotificationsSince.
+func (s *SQLiteDB) AddNotification(playerID, realm, message, severity string) error {
+	if realm == "" {
+		realm = "main"
+	}
+	if severity == "" {
+		severity = NotificationInfo
+	}
+
+	_, err := s.conn.Exec(
+		`INSERT INTO notifications (player_id, realm, message, severity, created_at) VALUES (?, ?, ?, ?, ?)`,
+		playerID, realm, message, severity, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add notification for player %s: %w", playerID, err)
+	}
+
+	return nil
+}
+
+// GetNotificationsSince returns up to limit notifications recorded for
+// playerID strictly after since, oldest first, so a polling client can
+// advance since to the last entry's CreatedAt and never see a duplicate.
+func (s *SQLiteDB) GetNotificationsSince(playerID string, since time.Time, limit int) ([]*Notification, error) {
+	rows, err := s.conn.Query(
+		`SELECT id, player_id, realm, message, severity, created_at
+		 FROM notifications WHERE player_id = ? AND created_at > ? ORDER BY created_at LIMIT ?`,
+		playerID, since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications for player %s: %w", playerID, err)
+	}
+	defer rows.Close()
+
+	var notifications []*Notification
+	for rows.Next() {
+		var n Notification
+		if err := rows.Scan(&n.ID, &n.PlayerID, &n.Realm, &n.Message, &n.Severity, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+		notifications = append(notifications, &n)
+	}
+
+	return notifications, rows.Err()
+}
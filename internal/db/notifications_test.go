@@ -0,0 +1,97 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNotificationsSinceReturnsOnlyNewerEntries(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	cutoff := time.Now().UTC()
+	if err := sdb.AddNotification("p1", "main", "before cutoff", NotificationInfo); err != nil {
+		t.Fatalf("AddNotification() error = %v", err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cutoff = time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+
+	if err := sdb.AddNotification("p1", "main", "after cutoff", NotificationInfo); err != nil {
+		t.Fatalf("AddNotification() error = %v", err)
+	}
+
+	notifications, err := sdb.GetNotificationsSince("p1", cutoff, 10)
+	if err != nil {
+		t.Fatalf("GetNotificationsSince() error = %v", err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("GetNotificationsSince() = %d notifications, want 1", len(notifications))
+	}
+	if notifications[0].Message != "after cutoff" {
+		t.Errorf("Message = %q, want %q", notifications[0].Message, "after cutoff")
+	}
+}
+
+func TestAddNotificationPersistsSeverity(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.AddNotification("p1", "main", "careful now", NotificationWarning); err != nil {
+		t.Fatalf("AddNotification() error = %v", err)
+	}
+
+	notifications, err := sdb.GetNotificationsSince("p1", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("GetNotificationsSince() error = %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].Severity != NotificationWarning {
+		t.Errorf("GetNotificationsSince() = %+v, want one notification with severity %q", notifications, NotificationWarning)
+	}
+}
+
+func TestAddNotificationDefaultsSeverityToInfo(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.AddNotification("p1", "main", "just fyi", ""); err != nil {
+		t.Fatalf("AddNotification() error = %v", err)
+	}
+
+	notifications, err := sdb.GetNotificationsSince("p1", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("GetNotificationsSince() error = %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].Severity != NotificationInfo {
+		t.Errorf("GetNotificationsSince() = %+v, want one notification with severity %q", notifications, NotificationInfo)
+	}
+}
+
+func TestGetNotificationsSinceZeroTimeReturnsEverything(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	for _, msg := range []string{"one", "two", "three"} {
+		if err := sdb.AddNotification("p1", "main", msg, NotificationInfo); err != nil {
+			t.Fatalf("AddNotification() error = %v", err)
+		}
+	}
+
+	notifications, err := sdb.GetNotificationsSince("p1", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("GetNotificationsSince() error = %v", err)
+	}
+	if len(notifications) != 3 {
+		t.Errorf("GetNotificationsSince() = %d notifications, want 3", len(notifications))
+	}
+}
@@ -0,0 +1,322 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/webhook"
+)
+
+// LevelMilestones are the player levels that trigger a
+// webhook.EventPlayerLevelUp notification the moment a player reaches them.
+// It's exported so other packages (e.g. a GraphQL "achievements" resolver)
+// can treat the same levels as award-worthy without duplicating the list.
+var LevelMilestones = []int{10, 25, 50, 75, 100}
+
+// crossedMilestone reports the highest milestone in LevelMilestones that
+// lies in (oldLevel, newLevel], if any. A player who jumps several
+// milestones in one save (e.g. after an offline-earnings catch-up) is
+// reported at the highest one they reached.
+func crossedMilestone(oldLevel, newLevel int) (int, bool) {
+	milestone, reached := 0, false
+	for _, m := range LevelMilestones {
+		if oldLevel < m && newLevel >= m {
+			milestone, reached = m, true
+		}
+	}
+	return milestone, reached
+}
+
+// NotifyingDB wraps a Database, firing webhook notifications for
+// player-facing milestones (level-ups, new leaderboard leaders) as a
+// side effect of the writes that cause them. Delivery is handled by the
+// wrapped Notifier and never blocks or fails these calls.
+type NotifyingDB struct {
+	db       Database
+	notifier *webhook.Notifier
+}
+
+var _ Database = (*NotifyingDB)(nil)
+
+// NewNotifyingDB wraps db, dispatching milestone events through notifier.
+func NewNotifyingDB(db Database, notifier *webhook.Notifier) *NotifyingDB {
+	return &NotifyingDB{db: db, notifier: notifier}
+}
+
+func (n *NotifyingDB) notifyLevelUp(playerID, realm string, oldLevel, newLevel int) {
+	milestone, reached := crossedMilestone(oldLevel, newLevel)
+	if !reached {
+		return
+	}
+	n.notifier.Notify(webhook.Event{
+		Type: webhook.EventPlayerLevelUp,
+		Data: map[string]any{"player_id": playerID, "realm": realm, "level": milestone},
+	})
+	// Best-effort: the webhook above has already fired, so a storage
+	// hiccup persisting the notification shouldn't fail the request.
+	_ = n.db.AddNotification(playerID, realm, fmt.Sprintf("Reached level %d!", milestone), NotificationSuccess)
+}
+
+func (n *NotifyingDB) SaveGameState(state *GameState) error {
+	prev, _ := n.db.GetGameState(state.PlayerID, state.Realm)
+
+	if err := n.db.SaveGameState(state); err != nil {
+		return err
+	}
+
+	oldLevel := 0
+	if prev != nil {
+		oldLevel = prev.CurrentLevel
+	}
+	n.notifyLevelUp(state.PlayerID, state.Realm, oldLevel, state.CurrentLevel)
+	return nil
+}
+
+func (n *NotifyingDB) PurchaseUpgrade(playerID, upgradeType string, level int, state *GameState) error {
+	prev, _ := n.db.GetGameState(playerID, state.Realm)
+
+	if err := n.db.PurchaseUpgrade(playerID, upgradeType, level, state); err != nil {
+		return err
+	}
+
+	oldLevel := 0
+	if prev != nil {
+		oldLevel = prev.CurrentLevel
+	}
+	n.notifyLevelUp(playerID, state.Realm, oldLevel, state.CurrentLevel)
+	return nil
+}
+
+func (n *NotifyingDB) GetUpgradeLevels(playerID string) (map[string]int, error) {
+	return n.db.GetUpgradeLevels(playerID)
+}
+
+func (n *NotifyingDB) UpdateLeaderboard(entry *LeaderboardEntry) error {
+	prevTop, _ := n.db.GetLeaderboard(entry.Realm, SortByKeystrokes, 0, 1, 0)
+
+	if err := n.db.UpdateLeaderboard(entry); err != nil {
+		return err
+	}
+
+	wasLeader := len(prevTop) > 0 && prevTop[0].PlayerID == entry.PlayerID
+	if wasLeader {
+		return nil
+	}
+
+	newTop, err := n.db.GetLeaderboard(entry.Realm, SortByKeystrokes, 0, 1, 0)
+	if err != nil || len(newTop) == 0 || newTop[0].PlayerID != entry.PlayerID {
+		return nil
+	}
+
+	n.notifier.Notify(webhook.Event{
+		Type: webhook.EventNewLeaderboardLeader,
+		Data: map[string]any{"player_id": entry.PlayerID, "username": entry.Username, "realm": entry.Realm},
+	})
+	return nil
+}
+
+func (n *NotifyingDB) GetPlayer(id string) (*Player, error) {
+	return n.db.GetPlayer(id)
+}
+
+func (n *NotifyingDB) GetPlayerByUsername(username string) (*Player, error) {
+	return n.db.GetPlayerByUsername(username)
+}
+
+func (n *NotifyingDB) GetPlayerByPublicKey(publicKey string) (*Player, error) {
+	return n.db.GetPlayerByPublicKey(publicKey)
+}
+
+func (n *NotifyingDB) SavePlayer(player *Player) error {
+	return n.db.SavePlayer(player)
+}
+
+func (n *NotifyingDB) CreateOrGetPlayer(id, username, publicKey, realm string) (*Player, error) {
+	return n.db.CreateOrGetPlayer(id, username, publicKey, realm)
+}
+
+func (n *NotifyingDB) CreateOrGetPlayerByKey(id, username, publicKey, realm string) (*Player, error) {
+	return n.db.CreateOrGetPlayerByKey(id, username, publicKey, realm)
+}
+
+func (n *NotifyingDB) DeletePlayer(id string) error {
+	return n.db.DeletePlayer(id)
+}
+
+func (n *NotifyingDB) SetPlayerBanned(id string, banned bool) error {
+	return n.db.SetPlayerBanned(id, banned)
+}
+
+func (n *NotifyingDB) SetPlayerSuspended(id string, suspended bool) error {
+	return n.db.SetPlayerSuspended(id, suspended)
+}
+
+func (n *NotifyingDB) RenamePlayer(id, username string) error {
+	return n.db.RenamePlayer(id, username)
+}
+
+func (n *NotifyingDB) RotatePlayerKey(id, publicKey string) error {
+	return n.db.RotatePlayerKey(id, publicKey)
+}
+
+func (n *NotifyingDB) UpdateLastActive(id string) error {
+	return n.db.UpdateLastActive(id)
+}
+
+func (n *NotifyingDB) StartSession(playerID, sourceIP string) (int64, error) {
+	return n.db.StartSession(playerID, sourceIP)
+}
+
+func (n *NotifyingDB) EndSession(id int64, bytesIn, bytesOut int64) error {
+	return n.db.EndSession(id, bytesIn, bytesOut)
+}
+
+func (n *NotifyingDB) GetLastSession(playerID string) (*SessionRecord, error) {
+	return n.db.GetLastSession(playerID)
+}
+
+func (n *NotifyingDB) Ping(ctx context.Context) error {
+	return n.db.Ping(ctx)
+}
+
+func (n *NotifyingDB) Stats() sql.DBStats {
+	return n.db.Stats()
+}
+
+func (n *NotifyingDB) SearchPlayers(query string, limit int) ([]*Player, error) {
+	return n.db.SearchPlayers(query, limit)
+}
+
+func (n *NotifyingDB) GetGameState(playerID, realm string) (*GameState, error) {
+	return n.db.GetGameState(playerID, realm)
+}
+
+func (n *NotifyingDB) GetLifetimeStats(playerID string) (*LifetimeStats, error) {
+	return n.db.GetLifetimeStats(playerID)
+}
+
+func (n *NotifyingDB) AddLifetimeStats(playerID string, delta LifetimeStats) error {
+	return n.db.AddLifetimeStats(playerID, delta)
+}
+
+func (n *NotifyingDB) SendFriendRequest(fromID, toID string) error {
+	return n.db.SendFriendRequest(fromID, toID)
+}
+
+func (n *NotifyingDB) AcceptFriendRequest(playerID, requesterID string) error {
+	return n.db.AcceptFriendRequest(playerID, requesterID)
+}
+
+func (n *NotifyingDB) RemoveFriend(playerID, friendID string) error {
+	return n.db.RemoveFriend(playerID, friendID)
+}
+
+func (n *NotifyingDB) GetFriends(playerID string) ([]*Friend, error) {
+	return n.db.GetFriends(playerID)
+}
+
+func (n *NotifyingDB) CreateGuild(guild *Guild) error {
+	return n.db.CreateGuild(guild)
+}
+
+func (n *NotifyingDB) GetGuild(id string) (*Guild, error) {
+	return n.db.GetGuild(id)
+}
+
+func (n *NotifyingDB) JoinGuild(guildID, playerID string) error {
+	return n.db.JoinGuild(guildID, playerID)
+}
+
+func (n *NotifyingDB) LeaveGuild(guildID, playerID string) error {
+	return n.db.LeaveGuild(guildID, playerID)
+}
+
+func (n *NotifyingDB) GetGuildMembers(guildID string) ([]*GuildMember, error) {
+	return n.db.GetGuildMembers(guildID)
+}
+
+func (n *NotifyingDB) GetLeaderboard(realm string, sort LeaderboardSort, minLevel, limit, offset int) ([]*LeaderboardEntry, error) {
+	return n.db.GetLeaderboard(realm, sort, minLevel, limit, offset)
+}
+
+func (n *NotifyingDB) CountLeaderboard(realm string, minLevel int) (int, error) {
+	return n.db.CountLeaderboard(realm, minLevel)
+}
+
+func (n *NotifyingDB) GetLeaderboardAround(realm string, sort LeaderboardSort, minLevel int, playerID string, rangeN int) ([]*LeaderboardEntry, error) {
+	return n.db.GetLeaderboardAround(realm, sort, minLevel, playerID, rangeN)
+}
+
+func (n *NotifyingDB) GetGlobalStats() (*GlobalStats, error) {
+	return n.db.GetGlobalStats()
+}
+
+func (n *NotifyingDB) AddConnectionBan(value, kind, reason string) error {
+	return n.db.AddConnectionBan(value, kind, reason)
+}
+
+func (n *NotifyingDB) RemoveConnectionBan(value string) error {
+	return n.db.RemoveConnectionBan(value)
+}
+
+func (n *NotifyingDB) ListConnectionBans() ([]*ConnectionBan, error) {
+	return n.db.ListConnectionBans()
+}
+
+func (n *NotifyingDB) RecordAuthAttempt(username, fingerprint, sourceIP string, success bool, reason string) error {
+	return n.db.RecordAuthAttempt(username, fingerprint, sourceIP, success, reason)
+}
+
+func (n *NotifyingDB) GetAuthAttempts(username string, limit int) ([]*AuthAttempt, error) {
+	return n.db.GetAuthAttempts(username, limit)
+}
+
+func (n *NotifyingDB) GetReplicationLog(afterID int64, limit int) ([]*ReplicationEvent, error) {
+	return n.db.GetReplicationLog(afterID, limit)
+}
+
+func (n *NotifyingDB) PruneReplicationLog(throughID int64) error {
+	return n.db.PruneReplicationLog(throughID)
+}
+
+func (n *NotifyingDB) AddNotification(playerID, realm, message, severity string) error {
+	return n.db.AddNotification(playerID, realm, message, severity)
+}
+
+func (n *NotifyingDB) GetNotificationsSince(playerID string, since time.Time, limit int) ([]*Notification, error) {
+	return n.db.GetNotificationsSince(playerID, since, limit)
+}
+
+func (n *NotifyingDB) IssueAPIToken(playerID string) (string, error) {
+	return n.db.IssueAPIToken(playerID)
+}
+
+func (n *NotifyingDB) RotateAPIToken(playerID string) (string, error) {
+	return n.db.RotateAPIToken(playerID)
+}
+
+func (n *NotifyingDB) RevokeAPIToken(token string) error {
+	return n.db.RevokeAPIToken(token)
+}
+
+func (n *NotifyingDB) AuthenticateAPIToken(token string) (*Player, error) {
+	return n.db.AuthenticateAPIToken(token)
+}
+
+func (n *NotifyingDB) IssuePairingCode() (string, error) {
+	return n.db.IssuePairingCode()
+}
+
+func (n *NotifyingDB) ClaimPairingCode(code, playerID string) error {
+	return n.db.ClaimPairingCode(code, playerID)
+}
+
+func (n *NotifyingDB) ResolvePairingCode(code string) (*Player, error) {
+	return n.db.ResolvePairingCode(code)
+}
+
+func (n *NotifyingDB) Close() error {
+	return n.db.Close()
+}
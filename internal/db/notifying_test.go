@@ -0,0 +1,141 @@
+package db
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/webhook"
+)
+
+// collectingWebhookServer records the types of events delivered to it.
+func collectingWebhookServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var types []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			Type string `json:"type"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode webhook body: %v", err)
+			return
+		}
+		mu.Lock()
+		types = append(types, event.Type)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), types...)
+	}
+}
+
+func waitForEvents(get func() []string, n int) []string {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if got := get(); len(got) >= n {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return get()
+}
+
+func TestNotifyingDBFiresLevelUpWebhook(t *testing.T) {
+	sdb := newTestDB(t)
+	srv, events := collectingWebhookServer(t)
+	ndb := NewNotifyingDB(sdb, webhook.NewNotifier(webhook.Config{URLs: []string{srv.URL}}))
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := ndb.SaveGameState(&GameState{PlayerID: "p1", CurrentLevel: 50}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	got := waitForEvents(events, 1)
+	if len(got) != 1 || got[0] != webhook.EventPlayerLevelUp {
+		t.Fatalf("delivered events = %v, want [%s]", got, webhook.EventPlayerLevelUp)
+	}
+}
+
+func TestNotifyingDBPersistsNotificationOnLevelUpMilestone(t *testing.T) {
+	sdb := newTestDB(t)
+	srv, _ := collectingWebhookServer(t)
+	ndb := NewNotifyingDB(sdb, webhook.NewNotifier(webhook.Config{URLs: []string{srv.URL}}))
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := ndb.SaveGameState(&GameState{PlayerID: "p1", CurrentLevel: 50}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	notifications, err := sdb.GetNotificationsSince("p1", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("GetNotificationsSince() error = %v", err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("GetNotificationsSince() = %d notifications, want 1", len(notifications))
+	}
+}
+
+func TestNotifyingDBSkipsLevelUpWebhookBelowMilestone(t *testing.T) {
+	sdb := newTestDB(t)
+	srv, events := collectingWebhookServer(t)
+	ndb := NewNotifyingDB(sdb, webhook.NewNotifier(webhook.Config{URLs: []string{srv.URL}}))
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := ndb.SaveGameState(&GameState{PlayerID: "p1", CurrentLevel: 3}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := events(); len(got) != 0 {
+		t.Fatalf("delivered events = %v, want none", got)
+	}
+}
+
+func TestNotifyingDBFiresNewLeaderboardLeaderWebhook(t *testing.T) {
+	sdb := newTestDB(t)
+	srv, events := collectingWebhookServer(t)
+	ndb := NewNotifyingDB(sdb, webhook.NewNotifier(webhook.Config{URLs: []string{srv.URL}}))
+
+	for _, id := range []string{"p1", "p2"} {
+		if err := sdb.SavePlayer(&Player{ID: id, Username: id}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", id, err)
+		}
+	}
+
+	if err := ndb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Username: "p1", Keystrokes: 10}); err != nil {
+		t.Fatalf("UpdateLeaderboard(p1) error = %v", err)
+	}
+	if err := ndb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p2", Username: "p2", Keystrokes: 20}); err != nil {
+		t.Fatalf("UpdateLeaderboard(p2) error = %v", err)
+	}
+
+	got := waitForEvents(events, 2)
+	if len(got) != 2 {
+		t.Fatalf("delivered events = %v, want 2 new-leader events", got)
+	}
+	for _, typ := range got {
+		if typ != webhook.EventNewLeaderboardLeader {
+			t.Errorf("event type = %q, want %q", typ, webhook.EventNewLeaderboardLeader)
+		}
+	}
+}
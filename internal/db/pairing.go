@@ -0,0 +1,100 @@
+package db
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pairingCodeTTL bounds how long a pairing code can be claimed before it
+// expires unclaimed, so a code left on an idle terminal can't be claimed
+// indefinitely.
+const pairingCodeTTL = 10 * time.Minute
+
+// IssuePairingCode generates a short, human-typeable code with no player
+// attached yet, for a client authenticating without a key (e.g. a mobile
+// SSH app with no key agent) to display while it waits for the code to be
+// claimed through the API from a device the player is already logged in
+// on. It expires after pairingCodeTTL if never claimed.
+func (s *SQLiteDB) IssuePairingCode() (string, error) {
+	code, err := randomPairingCode()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pairing code: %w", err)
+	}
+
+	now := time.Now().UTC()
+	_, err = s.conn.Exec(
+		`INSERT INTO pairing_codes (code_hash, created_at, expires_at) VALUES (?, ?, ?)`,
+		hashToken(code), now, now.Add(pairingCodeTTL),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to store pairing code: %w", err)
+	}
+
+	return code, nil
+}
+
+// ClaimPairingCode attaches playerID to code, so it resolves to that
+// player the next time ResolvePairingCode is called. It fails with
+// ErrNotFound if code doesn't exist or has already expired, and with
+// ErrAlreadyExists if it's already been claimed.
+func (s *SQLiteDB) ClaimPairingCode(code, playerID string) error {
+	now := time.Now().UTC()
+
+	var claimed sql.NullTime
+	row := s.conn.QueryRow(
+		`SELECT claimed_at FROM pairing_codes WHERE code_hash = ? AND expires_at > ?`,
+		hashToken(code), now,
+	)
+	if err := row.Scan(&claimed); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("pairing code: %w", ErrNotFound)
+		}
+		return fmt.Errorf("failed to look up pairing code: %w", err)
+	}
+	if claimed.Valid {
+		return fmt.Errorf("pairing code: %w", ErrAlreadyExists)
+	}
+
+	if _, err := s.conn.Exec(
+		`UPDATE pairing_codes SET player_id = ?, claimed_at = ? WHERE code_hash = ?`,
+		playerID, now, hashToken(code),
+	); err != nil {
+		return fmt.Errorf("failed to claim pairing code: %w", err)
+	}
+	return nil
+}
+
+// ResolvePairingCode returns the player a claimed code resolves to. It
+// returns ErrNotFound if code doesn't exist, has expired, or hasn't been
+// claimed yet, so a waiting SSH session can poll it without distinguishing
+// those cases from each other.
+func (s *SQLiteDB) ResolvePairingCode(code string) (*Player, error) {
+	var playerID sql.NullString
+	row := s.conn.QueryRow(
+		`SELECT player_id FROM pairing_codes WHERE code_hash = ? AND expires_at > ? AND claimed_at IS NOT NULL`,
+		hashToken(code), time.Now().UTC(),
+	)
+	if err := row.Scan(&playerID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("pairing code: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to look up pairing code: %w", err)
+	}
+
+	return s.GetPlayer(playerID.String)
+}
+
+// randomPairingCode generates an 8-character base32 code, uppercased and
+// stripped of padding, short enough to read off a terminal and type into a
+// web form without a key agent.
+func randomPairingCode() (string, error) {
+	raw := make([]byte, 5)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(strings.TrimRight(base32.StdEncoding.EncodeToString(raw), "=")), nil
+}
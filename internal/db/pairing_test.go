@@ -0,0 +1,66 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPairingCodeLifecycle(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	code, err := sdb.IssuePairingCode()
+	if err != nil {
+		t.Fatalf("IssuePairingCode() error = %v", err)
+	}
+
+	if _, err := sdb.ResolvePairingCode(code); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolvePairingCode() before claim error = %v, want ErrNotFound", err)
+	}
+
+	if err := sdb.ClaimPairingCode(code, "p1"); err != nil {
+		t.Fatalf("ClaimPairingCode() error = %v", err)
+	}
+
+	player, err := sdb.ResolvePairingCode(code)
+	if err != nil {
+		t.Fatalf("ResolvePairingCode() error = %v", err)
+	}
+	if player.ID != "p1" {
+		t.Errorf("ResolvePairingCode() player ID = %q, want p1", player.ID)
+	}
+}
+
+func TestClaimPairingCodeRejectsUnknownCode(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.ClaimPairingCode("NOTACODE", "p1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ClaimPairingCode() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClaimPairingCodeRejectsAlreadyClaimedCode(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SavePlayer(&Player{ID: "p2", Username: "gorilla"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	code, err := sdb.IssuePairingCode()
+	if err != nil {
+		t.Fatalf("IssuePairingCode() error = %v", err)
+	}
+	if err := sdb.ClaimPairingCode(code, "p1"); err != nil {
+		t.Fatalf("ClaimPairingCode() error = %v", err)
+	}
+
+	if err := sdb.ClaimPairingCode(code, "p2"); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("ClaimPairingCode() second claim error = %v, want ErrAlreadyExists", err)
+	}
+}
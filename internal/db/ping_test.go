@@ -0,0 +1,14 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+}
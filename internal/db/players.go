@@ -0,0 +1,368 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetPlayer looks up a player by ID.
+func (s *SQLiteDB) GetPlayer(id string) (*Player, error) {
+	row := s.conn.QueryRow(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active FROM players WHERE id = ?`,
+		id,
+	)
+
+	var p Player
+	if err := row.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("player %s: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get player %s: %w", id, err)
+	}
+
+	return &p, nil
+}
+
+// GetPlayerByUsername looks up a player by username.
+func (s *SQLiteDB) GetPlayerByUsername(username string) (*Player, error) {
+	row := s.conn.QueryRow(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active FROM players WHERE username = ?`,
+		username,
+	)
+
+	var p Player
+	if err := row.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("player %s: %w", username, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get player %s: %w", username, err)
+	}
+
+	return &p, nil
+}
+
+// GetPlayerByPublicKey looks up a player by their registered SSH public
+// key. This is a player's real identity: unlike GetPlayerByUsername, it
+// isn't affected by them typing a different username at connection time.
+func (s *SQLiteDB) GetPlayerByPublicKey(publicKey string) (*Player, error) {
+	row := s.conn.QueryRow(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active FROM players WHERE public_key = ?`,
+		publicKey,
+	)
+
+	var p Player
+	if err := row.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("player with key: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get player by public key: %w", err)
+	}
+
+	return &p, nil
+}
+
+// SavePlayer inserts or updates a player record.
+func (s *SQLiteDB) SavePlayer(player *Player) error {
+	now := time.Now().UTC()
+	if player.CreatedAt.IsZero() {
+		player.CreatedAt = now
+	}
+	player.UpdatedAt = now
+	if player.Realm == "" {
+		player.Realm = "main"
+	}
+
+	_, err := s.conn.Exec(
+		`INSERT INTO players (id, username, public_key, realm, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		     username = excluded.username,
+		     public_key = excluded.public_key,
+		     realm = excluded.realm,
+		     updated_at = excluded.updated_at`,
+		player.ID, player.Username, player.PublicKey, player.Realm, player.CreatedAt, player.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save player %s: %w", player.ID, err)
+	}
+
+	return nil
+}
+
+// RenamePlayer changes a player's username. It returns ErrAlreadyExists if
+// another player already has that username.
+func (s *SQLiteDB) RenamePlayer(id, username string) error {
+	now := time.Now().UTC()
+	res, err := s.conn.Exec(
+		`UPDATE players SET username = ?, updated_at = ? WHERE id = ?`,
+		username, now, id,
+	)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return fmt.Errorf("username %s: %w", username, ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to rename player %s to %s: %w", id, username, err)
+	}
+	return checkRowsAffected(res, id)
+}
+
+// RotatePlayerKey replaces a player's registered SSH public key, e.g. after
+// they generate a new keypair.
+func (s *SQLiteDB) RotatePlayerKey(id, publicKey string) error {
+	res, err := s.conn.Exec(
+		`UPDATE players SET public_key = ?, updated_at = ? WHERE id = ?`,
+		publicKey, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to rotate key for player %s: %w", id, err)
+	}
+	return checkRowsAffected(res, id)
+}
+
+// isUniqueConstraintError reports whether err was caused by a SQLite UNIQUE
+// constraint violation. Checked by message rather than driver-specific error
+// type so it works under both the default and sqlcipher build tags.
+func isUniqueConstraintError(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// SearchPlayers returns players across all realms whose username contains
+// query (case-insensitive), ordered by username, for admin/moderation
+// lookups that should act regardless of which world a player is in.
+func (s *SQLiteDB) SearchPlayers(query string, limit int) ([]*Player, error) {
+	rows, err := s.conn.Query(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active
+		 FROM players
+		 WHERE username LIKE '%' || ? || '%' ESCAPE '\'
+		 ORDER BY username
+		 LIMIT ?`,
+		escapeLike(query), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search players for %q: %w", query, err)
+	}
+	defer rows.Close()
+
+	var players []*Player
+	for rows.Next() {
+		var p Player
+		if err := rows.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive); err != nil {
+			return nil, fmt.Errorf("failed to scan player row: %w", err)
+		}
+		players = append(players, &p)
+	}
+
+	return players, rows.Err()
+}
+
+// escapeLike escapes LIKE wildcards so a query containing % or _ is matched
+// literally instead of as a pattern.
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
+
+// SetPlayerBanned sets or clears a player's banned flag. Banned players are
+// refused at SSH auth and hidden from the leaderboard.
+func (s *SQLiteDB) SetPlayerBanned(id string, banned bool) error {
+	res, err := s.conn.Exec(
+		`UPDATE players SET banned = ?, updated_at = ? WHERE id = ?`,
+		banned, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set banned=%t for player %s: %w", banned, id, err)
+	}
+	return checkRowsAffected(res, id)
+}
+
+// SetPlayerSuspended sets or clears a player's suspended flag. Unlike a ban,
+// a suspension is expected to be temporary.
+func (s *SQLiteDB) SetPlayerSuspended(id string, suspended bool) error {
+	res, err := s.conn.Exec(
+		`UPDATE players SET suspended = ?, updated_at = ? WHERE id = ?`,
+		suspended, time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set suspended=%t for player %s: %w", suspended, id, err)
+	}
+	return checkRowsAffected(res, id)
+}
+
+// UpdateLastActive stamps id's last-active time to now. It's called
+// whenever a connected player's session shows real activity (a keystroke,
+// a resized terminal), so idle timeouts and playtime stats reflect what
+// they're actually doing rather than just when they connected.
+func (s *SQLiteDB) UpdateLastActive(id string) error {
+	res, err := s.conn.Exec(
+		`UPDATE players SET last_active = ? WHERE id = ?`,
+		time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update last active time for player %s: %w", id, err)
+	}
+	return checkRowsAffected(res, id)
+}
+
+func checkRowsAffected(res sql.Result, playerID string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for player %s: %w", playerID, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("player %s: %w", playerID, ErrNotFound)
+	}
+	return nil
+}
+
+// CreateOrGetPlayer returns the existing player with the given username, or
+// creates one with the given ID/public key/realm if none exists yet. This is
+// the entry point for first-connection registration, where the caller
+// doesn't know ahead of time whether the player is new. realm defaults to
+// "main" when empty, selecting which independent game world the player's
+// account belongs to.
+func (s *SQLiteDB) CreateOrGetPlayer(id, username, publicKey, realm string) (*Player, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var p Player
+	row := tx.QueryRow(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active
+		 FROM players WHERE username = ?`,
+		username,
+	)
+	err = row.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive)
+	if err == nil {
+		return &p, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up player %s: %w", username, err)
+	}
+
+	now := time.Now().UTC()
+	p = Player{ID: id, Username: username, PublicKey: publicKey, Realm: realm, CreatedAt: now, UpdatedAt: now, LastActive: now}
+	_, err = tx.Exec(
+		`INSERT INTO players (id, username, public_key, realm, created_at, updated_at, last_active) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		p.ID, p.Username, p.PublicKey, p.Realm, p.CreatedAt, p.UpdatedAt, p.LastActive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create player %s: %w", username, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit player creation: %w", err)
+	}
+
+	return &p, nil
+}
+
+// CreateOrGetPlayerByKey returns the existing player with the given public
+// key, or creates one with the given ID/username/realm if none exists yet.
+// Unlike CreateOrGetPlayer, identity is keyed by the SSH key rather than
+// the username, so the same key always resolves to the same player
+// regardless of what username the client connects with. It returns
+// ErrAlreadyExists if username belongs to a different key.
+func (s *SQLiteDB) CreateOrGetPlayerByKey(id, username, publicKey, realm string) (*Player, error) {
+	if realm == "" {
+		realm = "main"
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var p Player
+	row := tx.QueryRow(
+		`SELECT id, username, public_key, realm, banned, suspended, created_at, updated_at, last_active
+		 FROM players WHERE public_key = ?`,
+		publicKey,
+	)
+	err = row.Scan(&p.ID, &p.Username, &p.PublicKey, &p.Realm, &p.Banned, &p.Suspended, &p.CreatedAt, &p.UpdatedAt, &p.LastActive)
+	if err == nil {
+		return &p, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up player by public key: %w", err)
+	}
+
+	now := time.Now().UTC()
+	p = Player{ID: id, Username: username, PublicKey: publicKey, Realm: realm, CreatedAt: now, UpdatedAt: now, LastActive: now}
+	_, err = tx.Exec(
+		`INSERT INTO players (id, username, public_key, realm, created_at, updated_at, last_active) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		p.ID, p.Username, p.PublicKey, p.Realm, p.CreatedAt, p.UpdatedAt, p.LastActive,
+	)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, fmt.Errorf("username %s: %w", username, ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create player %s: %w", username, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit player creation: %w", err)
+	}
+
+	return &p, nil
+}
+
+// DeletePlayer permanently removes a player and every row across the schema
+// that references them - game state, leaderboard entries, session history,
+// purchased upgrades, lifetime stats, friendships, guild membership, API
+// tokens, notifications, and pairing codes - in a single transaction, so a
+// GDPR deletion request never leaves personal data behind and a failed
+// deletion never leaves orphaned rows either. If the player owns a guild,
+// ownership is transferred to another member (or the guild is disbanded if
+// they were the last one) before the player's own rows are removed. Every
+// statement here must be kept in sync with any new table that gains a
+// player_id (or similar) column.
+func (s *SQLiteDB) DeletePlayer(id string) error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := transferOrDisbandOwnedGuilds(tx, id); err != nil {
+		return fmt.Errorf("failed to delete player %s: %w", id, err)
+	}
+
+	statements := []string{
+		`DELETE FROM leaderboard_entries WHERE player_id = ?`,
+		`DELETE FROM game_states WHERE player_id = ?`,
+		`DELETE FROM sessions WHERE player_id = ?`,
+		`DELETE FROM player_upgrades WHERE player_id = ?`,
+		`DELETE FROM lifetime_stats WHERE player_id = ?`,
+		`DELETE FROM guild_members WHERE player_id = ?`,
+		`DELETE FROM api_tokens WHERE player_id = ?`,
+		`DELETE FROM notifications WHERE player_id = ?`,
+		`DELETE FROM pairing_codes WHERE player_id = ?`,
+		`DELETE FROM players WHERE id = ?`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt, id); err != nil {
+			return fmt.Errorf("failed to delete player %s: %w", id, err)
+		}
+	}
+
+	// friendships has two player-scoped columns, since a friendship links
+	// two players either of whom might be the one being deleted.
+	if _, err := tx.Exec(`DELETE FROM friendships WHERE player_id = ? OR friend_id = ?`, id, id); err != nil {
+		return fmt.Errorf("failed to delete player %s: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deletion of player %s: %w", id, err)
+	}
+
+	return nil
+}
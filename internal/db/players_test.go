@@ -0,0 +1,374 @@
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newTestDB(t *testing.T) *SQLiteDB {
+	t.Helper()
+
+	sdb, err := NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+
+	return sdb
+}
+
+func TestGetPlayerByUsername(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	player, err := sdb.GetPlayerByUsername("monkey")
+	if err != nil {
+		t.Fatalf("GetPlayerByUsername() error = %v", err)
+	}
+	if player.ID != "p1" {
+		t.Errorf("ID = %q, want %q", player.ID, "p1")
+	}
+}
+
+func TestGetPlayerByUsernameNotFound(t *testing.T) {
+	sdb := newTestDB(t)
+
+	_, err := sdb.GetPlayerByUsername("nobody")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetPlayerByUsername() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestDeletePlayerCascades(t *testing.T) {
+	sdb := newTestDB(t)
+
+	player := &Player{ID: "p1", Username: "monkey"}
+	friend := &Player{ID: "p2", Username: "friend"}
+	if err := sdb.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SavePlayer(friend); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SaveGameState(&GameState{PlayerID: "p1", CurrentLevel: 1}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+	if _, err := sdb.StartSession("p1", "127.0.0.1"); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if err := sdb.PurchaseUpgrade("p1", "keyboard", 1, &GameState{PlayerID: "p1", CurrentLevel: 1}); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+	if err := sdb.AddLifetimeStats("p1", LifetimeStats{TotalKeystrokes: 10}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+	if err := sdb.SendFriendRequest("p1", "p2"); err != nil {
+		t.Fatalf("SendFriendRequest() error = %v", err)
+	}
+	if err := sdb.CreateGuild(&Guild{ID: "g1", Name: "Monkeys", OwnerID: "p2"}); err != nil {
+		t.Fatalf("CreateGuild() error = %v", err)
+	}
+	if err := sdb.JoinGuild("g1", "p1"); err != nil {
+		t.Fatalf("JoinGuild() error = %v", err)
+	}
+	if _, err := sdb.IssueAPIToken("p1"); err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+	if err := sdb.AddNotification("p1", "main", "hello", NotificationInfo); err != nil {
+		t.Fatalf("AddNotification() error = %v", err)
+	}
+	code, err := sdb.IssuePairingCode()
+	if err != nil {
+		t.Fatalf("IssuePairingCode() error = %v", err)
+	}
+	if err := sdb.ClaimPairingCode(code, "p1"); err != nil {
+		t.Fatalf("ClaimPairingCode() error = %v", err)
+	}
+
+	if err := sdb.DeletePlayer("p1"); err != nil {
+		t.Fatalf("DeletePlayer() error = %v", err)
+	}
+
+	if _, err := sdb.GetPlayer("p1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPlayer() error = %v, want ErrNotFound", err)
+	}
+	if _, err := sdb.GetGameState("p1", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetGameState() error = %v, want ErrNotFound", err)
+	}
+
+	entries, err := sdb.GetLeaderboard("", SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("GetLeaderboard() = %d entries, want 0", len(entries))
+	}
+
+	for table, query := range map[string]string{
+		"sessions":        `SELECT COUNT(*) FROM sessions WHERE player_id = ?`,
+		"player_upgrades": `SELECT COUNT(*) FROM player_upgrades WHERE player_id = ?`,
+		"lifetime_stats":  `SELECT COUNT(*) FROM lifetime_stats WHERE player_id = ?`,
+		"guild_members":   `SELECT COUNT(*) FROM guild_members WHERE player_id = ?`,
+		"api_tokens":      `SELECT COUNT(*) FROM api_tokens WHERE player_id = ?`,
+		"notifications":   `SELECT COUNT(*) FROM notifications WHERE player_id = ?`,
+		"pairing_codes":   `SELECT COUNT(*) FROM pairing_codes WHERE player_id = ?`,
+	} {
+		var count int
+		if err := sdb.conn.QueryRow(query, "p1").Scan(&count); err != nil {
+			t.Fatalf("counting %s error = %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("%s has %d rows referencing p1 after DeletePlayer(), want 0", table, count)
+		}
+	}
+
+	var friendships int
+	if err := sdb.conn.QueryRow(`SELECT COUNT(*) FROM friendships WHERE player_id = ? OR friend_id = ?`, "p1", "p1").Scan(&friendships); err != nil {
+		t.Fatalf("counting friendships error = %v", err)
+	}
+	if friendships != 0 {
+		t.Errorf("friendships has %d rows referencing p1 after DeletePlayer(), want 0", friendships)
+	}
+}
+
+func TestDeletePlayerTransfersOwnedGuildToAnotherMember(t *testing.T) {
+	sdb := newTestDB(t)
+
+	owner := &Player{ID: "p1", Username: "monkey"}
+	member := &Player{ID: "p2", Username: "friend"}
+	if err := sdb.SavePlayer(owner); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SavePlayer(member); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.CreateGuild(&Guild{ID: "g1", Name: "Monkeys", OwnerID: "p1"}); err != nil {
+		t.Fatalf("CreateGuild() error = %v", err)
+	}
+	if err := sdb.JoinGuild("g1", "p2"); err != nil {
+		t.Fatalf("JoinGuild() error = %v", err)
+	}
+
+	if err := sdb.DeletePlayer("p1"); err != nil {
+		t.Fatalf("DeletePlayer() error = %v", err)
+	}
+
+	guild, err := sdb.GetGuild("g1")
+	if err != nil {
+		t.Fatalf("GetGuild() error = %v", err)
+	}
+	if guild.OwnerID != "p2" {
+		t.Errorf("GetGuild().OwnerID = %s, want p2 to inherit ownership", guild.OwnerID)
+	}
+
+	members, err := sdb.GetGuildMembers("g1")
+	if err != nil {
+		t.Fatalf("GetGuildMembers() error = %v", err)
+	}
+	if len(members) != 1 || members[0].PlayerID != "p2" || members[0].Role != GuildRoleOwner {
+		t.Errorf("GetGuildMembers() = %+v, want p2 as the sole owner", members)
+	}
+}
+
+func TestDeletePlayerDisbandsGuildWithNoOtherMembers(t *testing.T) {
+	sdb := newTestDB(t)
+
+	owner := &Player{ID: "p1", Username: "monkey"}
+	if err := sdb.SavePlayer(owner); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.CreateGuild(&Guild{ID: "g1", Name: "Monkeys", OwnerID: "p1"}); err != nil {
+		t.Fatalf("CreateGuild() error = %v", err)
+	}
+
+	if err := sdb.DeletePlayer("p1"); err != nil {
+		t.Fatalf("DeletePlayer() error = %v", err)
+	}
+
+	if _, err := sdb.GetGuild("g1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetGuild() error = %v, want ErrNotFound after disbanding", err)
+	}
+
+	var members int
+	if err := sdb.conn.QueryRow(`SELECT COUNT(*) FROM guild_members WHERE guild_id = ?`, "g1").Scan(&members); err != nil {
+		t.Fatalf("counting guild_members error = %v", err)
+	}
+	if members != 0 {
+		t.Errorf("guild_members has %d rows for g1 after disbanding, want 0", members)
+	}
+}
+
+func TestSetPlayerBannedHidesFromLeaderboard(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Username: "monkey", Keystrokes: 100}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+
+	if err := sdb.SetPlayerBanned("p1", true); err != nil {
+		t.Fatalf("SetPlayerBanned() error = %v", err)
+	}
+
+	player, err := sdb.GetPlayer("p1")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if !player.Banned {
+		t.Error("player.Banned = false, want true")
+	}
+
+	entries, err := sdb.GetLeaderboard("", SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("GetLeaderboard() = %d entries, want 0 for banned player", len(entries))
+	}
+}
+
+func TestSetPlayerBannedUnknownPlayer(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SetPlayerBanned("ghost", true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetPlayerBanned() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSearchPlayers(t *testing.T) {
+	sdb := newTestDB(t)
+
+	for _, username := range []string{"monkeybusiness", "codemonkey", "typist"} {
+		if err := sdb.SavePlayer(&Player{ID: username, Username: username}); err != nil {
+			t.Fatalf("SavePlayer(%s) error = %v", username, err)
+		}
+	}
+
+	results, err := sdb.SearchPlayers("monkey", 10)
+	if err != nil {
+		t.Fatalf("SearchPlayers() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("SearchPlayers() = %d results, want 2", len(results))
+	}
+}
+
+func TestCreateOrGetPlayerCreatesThenReturnsExisting(t *testing.T) {
+	sdb := newTestDB(t)
+
+	created, err := sdb.CreateOrGetPlayer("id-1", "monkey", "ssh-ed25519 AAAA", "")
+	if err != nil {
+		t.Fatalf("CreateOrGetPlayer() error = %v", err)
+	}
+	if created.ID != "id-1" {
+		t.Fatalf("CreateOrGetPlayer() = %+v, want ID id-1", created)
+	}
+
+	again, err := sdb.CreateOrGetPlayer("id-2", "monkey", "ssh-ed25519 BBBB", "")
+	if err != nil {
+		t.Fatalf("CreateOrGetPlayer() error = %v", err)
+	}
+	if again.ID != "id-1" {
+		t.Fatalf("CreateOrGetPlayer() = %+v, want existing ID id-1", again)
+	}
+}
+
+func TestRenamePlayer(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.RenamePlayer("p1", "typist"); err != nil {
+		t.Fatalf("RenamePlayer() error = %v", err)
+	}
+
+	player, err := sdb.GetPlayer("p1")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if player.Username != "typist" {
+		t.Errorf("Username = %q, want %q", player.Username, "typist")
+	}
+}
+
+func TestRenamePlayerConflict(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.SavePlayer(&Player{ID: "p2", Username: "typist"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.RenamePlayer("p1", "typist"); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("RenamePlayer() error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestRenamePlayerNotFound(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.RenamePlayer("missing", "typist"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RenamePlayer() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRotatePlayerKey(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey", PublicKey: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.RotatePlayerKey("p1", "ssh-ed25519 BBBB"); err != nil {
+		t.Fatalf("RotatePlayerKey() error = %v", err)
+	}
+
+	player, err := sdb.GetPlayer("p1")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if player.PublicKey != "ssh-ed25519 BBBB" {
+		t.Errorf("PublicKey = %q, want %q", player.PublicKey, "ssh-ed25519 BBBB")
+	}
+}
+
+func TestUpdateLastActive(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.UpdateLastActive("p1"); err != nil {
+		t.Fatalf("UpdateLastActive() error = %v", err)
+	}
+
+	player, err := sdb.GetPlayer("p1")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if player.LastActive.IsZero() {
+		t.Error("LastActive is zero, want a timestamp after UpdateLastActive")
+	}
+}
+
+func TestUpdateLastActiveNotFound(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.UpdateLastActive("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateLastActive() error = %v, want ErrNotFound", err)
+	}
+}
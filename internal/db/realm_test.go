@@ -0,0 +1,65 @@
+package db
+
+import "testing"
+
+func TestGameStateIsolatedPerRealm(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.SaveGameState(&GameState{PlayerID: "p1", Realm: "main", CurrentLevel: 3}); err != nil {
+		t.Fatalf("SaveGameState(main) error = %v", err)
+	}
+	if err := sdb.SaveGameState(&GameState{PlayerID: "p1", Realm: "hardcore", CurrentLevel: 1}); err != nil {
+		t.Fatalf("SaveGameState(hardcore) error = %v", err)
+	}
+
+	main, err := sdb.GetGameState("p1", "main")
+	if err != nil {
+		t.Fatalf("GetGameState(main) error = %v", err)
+	}
+	if main.CurrentLevel != 3 {
+		t.Errorf("GetGameState(main).CurrentLevel = %d, want 3", main.CurrentLevel)
+	}
+
+	hardcore, err := sdb.GetGameState("p1", "hardcore")
+	if err != nil {
+		t.Fatalf("GetGameState(hardcore) error = %v", err)
+	}
+	if hardcore.CurrentLevel != 1 {
+		t.Errorf("GetGameState(hardcore).CurrentLevel = %d, want 1", hardcore.CurrentLevel)
+	}
+}
+
+func TestGetLeaderboardScopedToRealm(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Realm: "main", Username: "monkey", Keystrokes: 100}); err != nil {
+		t.Fatalf("UpdateLeaderboard(main) error = %v", err)
+	}
+	if err := sdb.UpdateLeaderboard(&LeaderboardEntry{PlayerID: "p1", Realm: "hardcore", Username: "monkey", Keystrokes: 5}); err != nil {
+		t.Fatalf("UpdateLeaderboard(hardcore) error = %v", err)
+	}
+
+	mainEntries, err := sdb.GetLeaderboard("main", SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard(main) error = %v", err)
+	}
+	if len(mainEntries) != 1 || mainEntries[0].Keystrokes != 100 {
+		t.Errorf("GetLeaderboard(main) = %+v, want one entry with 100 keystrokes", mainEntries)
+	}
+
+	hardcoreEntries, err := sdb.GetLeaderboard("hardcore", SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard(hardcore) error = %v", err)
+	}
+	if len(hardcoreEntries) != 1 || hardcoreEntries[0].Keystrokes != 5 {
+		t.Errorf("GetLeaderboard(hardcore) = %+v, want one entry with 5 keystrokes", hardcoreEntries)
+	}
+}
@@ -0,0 +1,39 @@
+package db
+
+import "fmt"
+
+// GetReplicationLog returns up to limit changelog entries recorded after
+// afterID (exclusive), ordered by ID. An external shipper polls this to
+// stream changes to object storage, then advances afterID past the highest
+// ID it has durably uploaded.
+func (s *SQLiteDB) GetReplicationLog(afterID int64, limit int) ([]*ReplicationEvent, error) {
+	rows, err := s.conn.Query(
+		`SELECT id, table_name, operation, row_id, recorded_at
+		 FROM replication_log WHERE id > ? ORDER BY id LIMIT ?`,
+		afterID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query replication log after %d: %w", afterID, err)
+	}
+	defer rows.Close()
+
+	var events []*ReplicationEvent
+	for rows.Next() {
+		var e ReplicationEvent
+		if err := rows.Scan(&e.ID, &e.TableName, &e.Operation, &e.RowID, &e.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan replication log row: %w", err)
+		}
+		events = append(events, &e)
+	}
+
+	return events, rows.Err()
+}
+
+// PruneReplicationLog deletes changelog entries up to and including
+// throughID, once a shipper has confirmed they were durably replicated.
+func (s *SQLiteDB) PruneReplicationLog(throughID int64) error {
+	if _, err := s.conn.Exec(`DELETE FROM replication_log WHERE id <= ?`, throughID); err != nil {
+		return fmt.Errorf("failed to prune replication log through %d: %w", throughID, err)
+	}
+	return nil
+}
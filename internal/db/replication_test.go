@@ -0,0 +1,39 @@
+package db
+
+import "testing"
+
+func TestReplicationLogRecordsPlayerWrites(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := sdb.DeletePlayer("p1"); err != nil {
+		t.Fatalf("DeletePlayer() error = %v", err)
+	}
+
+	events, err := sdb.GetReplicationLog(0, 10)
+	if err != nil {
+		t.Fatalf("GetReplicationLog() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("GetReplicationLog() = %d events, want 2", len(events))
+	}
+	if events[0].TableName != "players" || events[0].Operation != "insert" || events[0].RowID != "p1" {
+		t.Errorf("events[0] = %+v, want players insert p1", events[0])
+	}
+	if events[1].Operation != "delete" || events[1].RowID != "p1" {
+		t.Errorf("events[1] = %+v, want players delete p1", events[1])
+	}
+
+	if err := sdb.PruneReplicationLog(events[1].ID); err != nil {
+		t.Fatalf("PruneReplicationLog() error = %v", err)
+	}
+	remaining, err := sdb.GetReplicationLog(0, 10)
+	if err != nil {
+		t.Fatalf("GetReplicationLog() after prune error = %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("GetReplicationLog() after prune = %d events, want 0", len(remaining))
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// StartSession records the beginning of an SSH connection and returns the
+// new session's ID.
+func (s *SQLiteDB) StartSession(playerID, sourceIP string) (int64, error) {
+	res, err := s.conn.Exec(
+		`INSERT INTO sessions (player_id, source_ip, started_at) VALUES (?, ?, ?)`,
+		playerID, sourceIP, time.Now().UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start session for player %s: %w", playerID, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read session id: %w", err)
+	}
+
+	return id, nil
+}
+
+// EndSession records the end of an SSH connection along with the bytes
+// transferred over its lifetime.
+func (s *SQLiteDB) EndSession(id int64, bytesIn, bytesOut int64) error {
+	_, err := s.conn.Exec(
+		`UPDATE sessions SET ended_at = ?, bytes_in = ?, bytes_out = ? WHERE id = ?`,
+		time.Now().UTC(), bytesIn, bytesOut, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to end session %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetLastSession returns the most recent session recorded for a player, or
+// ErrNotFound if they have never connected.
+func (s *SQLiteDB) GetLastSession(playerID string) (*SessionRecord, error) {
+	row := s.conn.QueryRow(
+		`SELECT id, player_id, source_ip, started_at, ended_at, bytes_in, bytes_out
+		 FROM sessions WHERE player_id = ? ORDER BY started_at DESC LIMIT 1`,
+		playerID,
+	)
+
+	var rec SessionRecord
+	err := row.Scan(&rec.ID, &rec.PlayerID, &rec.SourceIP, &rec.StartedAt, &rec.EndedAt, &rec.BytesIn, &rec.BytesOut)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session for player %s: %w", playerID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last session for player %s: %w", playerID, err)
+	}
+
+	return &rec, nil
+}
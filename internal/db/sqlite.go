@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Options controls how the underlying SQLite connection is configured.
+type Options struct {
+	// WAL enables write-ahead logging, which lets readers and a writer
+	// proceed concurrently instead of serializing on a single lock.
+	// Defaults to true.
+	WAL bool
+	// BusyTimeout is how long a connection waits on a locked database
+	// before giving up. Defaults to 5s.
+	BusyTimeout time.Duration
+	// Synchronous is the SQLite synchronous pragma value (FULL, NORMAL,
+	// OFF). Defaults to NORMAL, which is safe under WAL.
+	Synchronous string
+	// EncryptionKey, if set, enables at-rest encryption of the SQLite file.
+	// It only takes effect in binaries built with `-tags sqlcipher`; a
+	// default build returns an error rather than silently storing
+	// plaintext when a key is configured.
+	EncryptionKey string
+	// MigrateTarget, if set, pins the schema to a specific migration (by its
+	// base name, e.g. "0003_sessions") instead of the latest. Migrations
+	// beyond the target are rolled back via their down step. Leave empty to
+	// always migrate to the latest schema.
+	MigrateTarget string
+}
+
+// DefaultOptions returns the tuning Term Idle uses in production: WAL mode
+// with a generous busy timeout so concurrent SSH sessions auto-saving don't
+// trip SQLITE_BUSY errors.
+func DefaultOptions() Options {
+	return Options{
+		WAL:         true,
+		BusyTimeout: 5 * time.Second,
+		Synchronous: "NORMAL",
+	}
+}
+
+// maxOpenConns bounds the connection pool. It's small enough that a runaway
+// number of concurrent SSH sessions can't exhaust file descriptors, but well
+// above 1 so concurrent readers don't queue behind each other under WAL mode.
+const maxOpenConns = 10
+
+var _ Database = (*SQLiteDB)(nil)
+
+// SQLiteDB is the SQLite-backed implementation of Database.
+type SQLiteDB struct {
+	conn *sql.DB
+}
+
+// NewSQLiteDB opens (creating if necessary) the SQLite database at path and
+// runs pending migrations. All writes from the rest of the application go
+// through this single *sql.DB, which pools and serializes access the way
+// database/sql expects. The requested pragmas (and, on a sqlcipher build,
+// the encryption key) are applied by newConnector to every connection the
+// pool opens, not just the one that happens to service this call.
+func NewSQLiteDB(path string, opts Options) (*SQLiteDB, error) {
+	if opts.EncryptionKey != "" && !encryptionSupported {
+		return nil, fmt.Errorf("encryption key set but this binary was built without sqlcipher support; rebuild with -tags sqlcipher")
+	}
+
+	conn := sql.OpenDB(newConnector(path, opts))
+
+	if err := applyMigrations(conn, opts.MigrateTarget); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// go-sqlite3 only allows one writer at a time regardless of WAL mode,
+	// but WAL lets any number of readers proceed concurrently with that
+	// writer. Capping the pool at maxOpenConns rather than 1 lets readers
+	// actually benefit from that; busy_timeout (applied per connection by
+	// newConnector) is what serializes writers, each waiting its turn for
+	// the lock instead of racing each other for it or blocking reads while
+	// they wait.
+	conn.SetMaxOpenConns(maxOpenConns)
+
+	return &SQLiteDB{conn: conn}, nil
+}
+
+// pragmaStatements returns the PRAGMA statements a connection needs applied
+// once, right after it's opened, to match opts. It's shared by every build's
+// newConnector so the tuning logic (and its defaults) lives in one place
+// regardless of which driver package a connection comes from.
+func pragmaStatements(opts Options) []string {
+	if opts.Synchronous == "" {
+		opts.Synchronous = "NORMAL"
+	}
+	if opts.BusyTimeout == 0 {
+		opts.BusyTimeout = 5 * time.Second
+	}
+
+	journalMode := "DELETE"
+	if opts.WAL {
+		journalMode = "WAL"
+	}
+
+	return []string{
+		fmt.Sprintf("PRAGMA journal_mode=%s;", journalMode),
+		fmt.Sprintf("PRAGMA busy_timeout=%d;", opts.BusyTimeout.Milliseconds()),
+		fmt.Sprintf("PRAGMA synchronous=%s;", opts.Synchronous),
+	}
+}
+
+// sqliteConnector opens every connection sql.DB's pool creates through a
+// single configured driver.Driver, so a driver's ConnectHook (where
+// newConnector applies pragmas and the encryption key) runs for each one -
+// unlike a one-shot db.Exec after sql.Open, which only ever reaches
+// whichever single connection happened to service that call.
+type sqliteConnector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Close releases the underlying connection pool.
+func (s *SQLiteDB) Close() error {
+	return s.conn.Close()
+}
+
+// Ping reports whether the database is actually reachable, rather than the
+// caller assuming it is because the process is still running.
+func (s *SQLiteDB) Ping(ctx context.Context) error {
+	return s.conn.PingContext(ctx)
+}
+
+// Stats exposes the underlying connection pool statistics.
+func (s *SQLiteDB) Stats() sql.DBStats {
+	return s.conn.Stats()
+}
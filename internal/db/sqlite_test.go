@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewSQLiteDBAppliesWALPragma(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	sdb, err := NewSQLiteDB(path, DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer sdb.Close()
+
+	var journalMode string
+	if err := sdb.conn.QueryRow("PRAGMA journal_mode;").Scan(&journalMode); err != nil {
+		t.Fatalf("failed to read journal_mode: %v", err)
+	}
+	if journalMode != "wal" {
+		t.Errorf("journal_mode = %q, want %q", journalMode, "wal")
+	}
+}
+
+func TestNewSQLiteDBWALDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	opts := DefaultOptions()
+	opts.WAL = false
+	sdb, err := NewSQLiteDB(path, opts)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer sdb.Close()
+
+	var journalMode string
+	if err := sdb.conn.QueryRow("PRAGMA journal_mode;").Scan(&journalMode); err != nil {
+		t.Fatalf("failed to read journal_mode: %v", err)
+	}
+	if journalMode != "delete" {
+		t.Errorf("journal_mode = %q, want %q", journalMode, "delete")
+	}
+}
+
+func TestNewSQLiteDBAllowsConcurrentReaderConnections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	sdb, err := NewSQLiteDB(path, DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer sdb.Close()
+
+	if got := sdb.Stats().MaxOpenConnections; got <= 1 {
+		t.Errorf("MaxOpenConnections = %d, want more than 1 so WAL readers aren't serialized behind each other", got)
+	}
+}
+
+func TestNewSQLiteDBAppliesPragmasToEveryPooledConnection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	opts := DefaultOptions()
+	opts.BusyTimeout = 30 * time.Second
+	opts.Synchronous = "FULL"
+	sdb, err := NewSQLiteDB(path, opts)
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer sdb.Close()
+
+	ctx := context.Background()
+
+	// Force the pool to open several distinct physical connections at once,
+	// rather than reusing the one NewSQLiteDB's migrations ran on, so this
+	// actually exercises ones that open(*sql.DB).Exec never touches.
+	const n = 5
+	conns := make([]*sql.Conn, n)
+	for i := range conns {
+		conn, err := sdb.conn.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn() error = %v", err)
+		}
+		conns[i] = conn
+	}
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for i, conn := range conns {
+		var busyTimeout int
+		if err := conn.QueryRowContext(ctx, "PRAGMA busy_timeout;").Scan(&busyTimeout); err != nil {
+			t.Fatalf("connection %d: failed to read busy_timeout: %v", i, err)
+		}
+		if busyTimeout != 30000 {
+			t.Errorf("connection %d: busy_timeout = %d, want 30000", i, busyTimeout)
+		}
+
+		var synchronous int
+		if err := conn.QueryRowContext(ctx, "PRAGMA synchronous;").Scan(&synchronous); err != nil {
+			t.Fatalf("connection %d: failed to read synchronous: %v", i, err)
+		}
+		// SQLite reports synchronous back as its numeric level rather than
+		// the name it was set with; FULL is 2.
+		if synchronous != 2 {
+			t.Errorf("connection %d: synchronous = %d, want 2 (FULL)", i, synchronous)
+		}
+	}
+}
+
+func TestNewSQLiteDBEncryptionRequiresSQLCipherBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	opts := DefaultOptions()
+	opts.EncryptionKey = "secret"
+	if _, err := NewSQLiteDB(path, opts); err == nil {
+		t.Error("NewSQLiteDB() error = nil, want error for encryption key without sqlcipher build")
+	}
+}
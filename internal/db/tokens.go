@@ -0,0 +1,115 @@
+package db
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// IssueAPIToken generates a new API token for playerID and stores only its
+// hash, so the plaintext token exists nowhere but the caller's response.
+// Issuing a token doesn't revoke any existing ones; use RotateAPIToken to
+// replace them.
+func (s *SQLiteDB) IssueAPIToken(playerID string) (string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate API token for %s: %w", playerID, err)
+	}
+
+	_, err = s.conn.Exec(
+		`INSERT INTO api_tokens (token_hash, player_id, created_at) VALUES (?, ?, ?)`,
+		hashToken(token), playerID, time.Now().UTC(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to store API token for %s: %w", playerID, err)
+	}
+
+	return token, nil
+}
+
+// RotateAPIToken revokes every existing API token for playerID and issues a
+// fresh one, for when a token may have leaked.
+func (s *SQLiteDB) RotateAPIToken(playerID string) (string, error) {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM api_tokens WHERE player_id = ?`, playerID); err != nil {
+		return "", fmt.Errorf("failed to revoke existing API tokens for %s: %w", playerID, err)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate API token for %s: %w", playerID, err)
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO api_tokens (token_hash, player_id, created_at) VALUES (?, ?, ?)`,
+		hashToken(token), playerID, time.Now().UTC(),
+	); err != nil {
+		return "", fmt.Errorf("failed to store API token for %s: %w", playerID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit API token rotation for %s: %w", playerID, err)
+	}
+
+	return token, nil
+}
+
+// RevokeAPIToken invalidates token immediately.
+func (s *SQLiteDB) RevokeAPIToken(token string) error {
+	res, err := s.conn.Exec(`DELETE FROM api_tokens WHERE token_hash = ?`, hashToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected revoking API token: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("API token: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// AuthenticateAPIToken looks up the player token belongs to, recording the
+// lookup as a use. It returns ErrNotFound for an unknown, revoked, or
+// never-issued token.
+func (s *SQLiteDB) AuthenticateAPIToken(token string) (*Player, error) {
+	hash := hashToken(token)
+	now := time.Now().UTC()
+
+	var playerID string
+	row := s.conn.QueryRow(`SELECT player_id FROM api_tokens WHERE token_hash = ?`, hash)
+	if err := row.Scan(&playerID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("API token: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to look up API token: %w", err)
+	}
+
+	if _, err := s.conn.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE token_hash = ?`, now, hash); err != nil {
+		return nil, fmt.Errorf("failed to record API token use: %w", err)
+	}
+
+	return s.GetPlayer(playerID)
+}
+
+func randomToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
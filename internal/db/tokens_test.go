@@ -0,0 +1,62 @@
+package db
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPITokenLifecycle(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	token, err := sdb.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	player, err := sdb.AuthenticateAPIToken(token)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIToken() error = %v", err)
+	}
+	if player.ID != "p1" {
+		t.Errorf("AuthenticateAPIToken() player ID = %q, want p1", player.ID)
+	}
+
+	if err := sdb.RevokeAPIToken(token); err != nil {
+		t.Fatalf("RevokeAPIToken() error = %v", err)
+	}
+	if _, err := sdb.AuthenticateAPIToken(token); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AuthenticateAPIToken() after revoke error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRotateAPITokenInvalidatesOldToken(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	oldToken, err := sdb.IssueAPIToken("p1")
+	if err != nil {
+		t.Fatalf("IssueAPIToken() error = %v", err)
+	}
+
+	newToken, err := sdb.RotateAPIToken("p1")
+	if err != nil {
+		t.Fatalf("RotateAPIToken() error = %v", err)
+	}
+	if newToken == oldToken {
+		t.Error("RotateAPIToken() returned the same token")
+	}
+
+	if _, err := sdb.AuthenticateAPIToken(oldToken); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AuthenticateAPIToken(old) error = %v, want ErrNotFound", err)
+	}
+	if _, err := sdb.AuthenticateAPIToken(newToken); err != nil {
+		t.Errorf("AuthenticateAPIToken(new) error = %v", err)
+	}
+}
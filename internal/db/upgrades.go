@@ -0,0 +1,103 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// PurchaseUpgrade records an upgrade purchase, recomputes state's
+// ProductionRate from every upgrade level the player now owns (including
+// this purchase), saves the resulting game state, and refreshes the
+// player's leaderboard standing, all in a single transaction, so a crash
+// partway through never leaves the purchase ledger, game state, and
+// leaderboard disagreeing with each other.
+func (s *SQLiteDB) PurchaseUpgrade(playerID, upgradeType string, level int, state *GameState) error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`INSERT INTO player_upgrades (player_id, upgrade_type, level, purchased_at) VALUES (?, ?, ?, ?)`,
+		playerID, upgradeType, level, time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record upgrade purchase for %s: %w", playerID, err)
+	}
+
+	levels, err := getUpgradeLevels(tx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to get upgrade levels for %s: %w", playerID, err)
+	}
+	state.UpgradeLevels = levels
+	state.ProductionRate = game.CalculateProductionRate(levels)
+
+	if err := saveGameState(tx, state); err != nil {
+		return fmt.Errorf("failed to save game state for %s: %w", playerID, err)
+	}
+
+	var username string
+	err = tx.QueryRow(`SELECT username FROM players WHERE id = ?`, playerID).Scan(&username)
+	if err != nil {
+		return fmt.Errorf("failed to look up username for %s: %w", playerID, err)
+	}
+	err = updateLeaderboard(tx, &LeaderboardEntry{
+		PlayerID:   playerID,
+		Realm:      state.Realm,
+		Username:   username,
+		Keystrokes: state.Keystrokes,
+		Words:      state.Words,
+		Programs:   state.Programs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update leaderboard for %s: %w", playerID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit upgrade purchase for %s: %w", playerID, err)
+	}
+
+	return nil
+}
+
+// GetUpgradeLevels returns the current level of every upgrade playerID has
+// purchased at least one level of, keyed by upgrade type. player_upgrades is
+// an append-only ledger of purchases rather than a table of current levels,
+// so the current level of each upgrade is the highest level ever recorded
+// for it.
+func (s *SQLiteDB) GetUpgradeLevels(playerID string) (map[string]int, error) {
+	levels, err := getUpgradeLevels(s.conn, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade levels for %s: %w", playerID, err)
+	}
+	return levels, nil
+}
+
+// getUpgradeLevels is shared between a plain GetUpgradeLevels and one
+// nested inside PurchaseUpgrade's transaction, using the queryer interface
+// from game_state.go.
+func getUpgradeLevels(q queryer, playerID string) (map[string]int, error) {
+	rows, err := q.Query(
+		`SELECT upgrade_type, MAX(level) FROM player_upgrades WHERE player_id = ? GROUP BY upgrade_type`,
+		playerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := make(map[string]int)
+	for rows.Next() {
+		var upgradeType string
+		var level int
+		if err := rows.Scan(&upgradeType, &level); err != nil {
+			return nil, err
+		}
+		levels[upgradeType] = level
+	}
+
+	return levels, rows.Err()
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+func TestPurchaseUpgradeSavesStateAtomically(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state := &GameState{PlayerID: "p1", CurrentLevel: 2, Keystrokes: 50}
+	if err := sdb.PurchaseUpgrade("p1", "faster_typing", 2, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+
+	got, err := sdb.GetGameState("p1", "")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	wantRate := game.CalculateProductionRate(map[string]int{"faster_typing": 2})
+	if got.CurrentLevel != 2 || got.ProductionRate != wantRate {
+		t.Errorf("GetGameState() = %+v, want level 2, rate %v", got, wantRate)
+	}
+
+	var count int
+	if err := sdb.conn.QueryRow(`SELECT COUNT(*) FROM player_upgrades WHERE player_id = ?`, "p1").Scan(&count); err != nil {
+		t.Fatalf("failed to count purchases: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("player_upgrades count = %d, want 1", count)
+	}
+}
+
+func TestPurchaseUpgradeIncreasesProductionRate(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state := &GameState{PlayerID: "p1", ProductionRate: game.BaseProductionRate}
+	before := state.ProductionRate
+
+	if err := sdb.PurchaseUpgrade("p1", "faster_typing", 1, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+	if state.ProductionRate <= before {
+		t.Fatalf("ProductionRate = %v after first purchase, want more than %v", state.ProductionRate, before)
+	}
+
+	afterFirst := state.ProductionRate
+	if err := sdb.PurchaseUpgrade("p1", "faster_typing", 2, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+	if state.ProductionRate <= afterFirst {
+		t.Fatalf("ProductionRate = %v after second purchase, want more than %v", state.ProductionRate, afterFirst)
+	}
+
+	got, err := sdb.GetGameState("p1", "")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if got.ProductionRate != state.ProductionRate {
+		t.Errorf("persisted ProductionRate = %v, want %v", got.ProductionRate, state.ProductionRate)
+	}
+}
+
+func TestPurchaseUpgradeUpdatesLeaderboard(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state := &GameState{PlayerID: "p1", Keystrokes: 500, Words: 10, Programs: 1}
+	if err := sdb.PurchaseUpgrade("p1", "faster_typing", 1, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+
+	entries, err := sdb.GetLeaderboard("main", SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Keystrokes != 500 || entries[0].Username != "monkey" {
+		t.Errorf("GetLeaderboard() = %+v, want one entry for monkey with 500 keystrokes", entries)
+	}
+}
+
+func TestGetUpgradeLevelsReturnsHighestLevelPerType(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state := &GameState{PlayerID: "p1"}
+	for _, level := range []int{1, 2, 3} {
+		if err := sdb.PurchaseUpgrade("p1", "faster_typing", level, state); err != nil {
+			t.Fatalf("PurchaseUpgrade() error = %v", err)
+		}
+	}
+	if err := sdb.PurchaseUpgrade("p1", "auto_complete", 1, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+
+	levels, err := sdb.GetUpgradeLevels("p1")
+	if err != nil {
+		t.Fatalf("GetUpgradeLevels() error = %v", err)
+	}
+	if levels["faster_typing"] != 3 {
+		t.Errorf("levels[faster_typing] = %d, want 3", levels["faster_typing"])
+	}
+	if levels["auto_complete"] != 1 {
+		t.Errorf("levels[auto_complete] = %d, want 1", levels["auto_complete"])
+	}
+}
+
+func TestGetGameStatePopulatesUpgradeLevels(t *testing.T) {
+	sdb := newTestDB(t)
+
+	if err := sdb.SavePlayer(&Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state := &GameState{PlayerID: "p1"}
+	if err := sdb.PurchaseUpgrade("p1", "faster_typing", 2, state); err != nil {
+		t.Fatalf("PurchaseUpgrade() error = %v", err)
+	}
+
+	got, err := sdb.GetGameState("p1", "")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if got.UpgradeLevels["faster_typing"] != 2 {
+		t.Errorf("UpgradeLevels[faster_typing] = %d, want 2", got.UpgradeLevels["faster_typing"])
+	}
+}
@@ -0,0 +1,55 @@
+// Package game holds static content definitions for Term Idle's mechanics,
+// starting with the Monkey's story progression from random keystrokes to AI
+// programmer.
+package game
+
+// Chapter is one beat of the Monkey's story, unlocked once a player reaches
+// UnlockLevel.
+type Chapter struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	UnlockLevel int    `json:"unlock_level"`
+	Content     string `json:"content"`
+}
+
+// chapters is ordered by UnlockLevel; UnlockedChapters relies on that order
+// to stop early once it finds a chapter the player hasn't reached.
+var chapters = []Chapter{
+	{ID: 1, Title: "Random Keys", UnlockLevel: 1,
+		Content: "A monkey sits down at a terminal for the first time. Keys go down. Letters appear. Nobody, least of all the monkey, has any idea why."},
+	{ID: 2, Title: "Finding Words", UnlockLevel: 5,
+		Content: "Somewhere between ten thousand keystrokes and ten thousand and one, a pattern emerges: some of the noise is starting to look like words."},
+	{ID: 3, Title: "Hello, World", UnlockLevel: 10,
+		Content: "The monkey types `print(\"hello world\")` and, against every reasonable expectation, the terminal prints hello world back."},
+	{ID: 4, Title: "Writing Programs", UnlockLevel: 20,
+		Content: "One line becomes a function. A function becomes a program. The monkey still doesn't know what a semicolon is for, but it has opinions now."},
+	{ID: 5, Title: "Learning AI", UnlockLevel: 35,
+		Content: "The monkey discovers that it can write a program whose whole job is to write other programs. This feels like cheating. It is not stopping."},
+	{ID: 6, Title: "The Architect", UnlockLevel: 50,
+		Content: "The terminal is quiet. The monkey hasn't typed a line in an hour. Somewhere, a pipeline it designed is still typing for it."},
+}
+
+// Chapters returns every story chapter, regardless of unlock state.
+func Chapters() []Chapter {
+	return chapters
+}
+
+// UnlockedChapters returns the chapters a player at level has reached.
+func UnlockedChapters(level int) []Chapter {
+	var unlocked []Chapter
+	for _, c := range chapters {
+		if level >= c.UnlockLevel {
+			unlocked = append(unlocked, c)
+		}
+	}
+	return unlocked
+}
+
+// StoryProgressPercent returns how far through the story a player at level
+// is, from 0 to 100.
+func StoryProgressPercent(level int) float64 {
+	if len(chapters) == 0 {
+		return 0
+	}
+	return float64(len(UnlockedChapters(level))) / float64(len(chapters)) * 100
+}
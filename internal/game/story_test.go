@@ -0,0 +1,34 @@
+package game
+
+import "testing"
+
+func TestUnlockedChaptersAtLowLevel(t *testing.T) {
+	unlocked := UnlockedChapters(1)
+	if len(unlocked) != 1 || unlocked[0].Title != "Random Keys" {
+		t.Fatalf("UnlockedChapters(1) = %+v, want [Random Keys]", unlocked)
+	}
+}
+
+func TestUnlockedChaptersAtHighLevel(t *testing.T) {
+	unlocked := UnlockedChapters(100)
+	if len(unlocked) != len(Chapters()) {
+		t.Fatalf("UnlockedChapters(100) = %d chapters, want all %d", len(unlocked), len(Chapters()))
+	}
+}
+
+func TestChaptersHaveContent(t *testing.T) {
+	for _, c := range Chapters() {
+		if c.Content == "" {
+			t.Errorf("Chapter %d (%s) has no content", c.ID, c.Title)
+		}
+	}
+}
+
+func TestStoryProgressPercent(t *testing.T) {
+	if got := StoryProgressPercent(0); got != 0 {
+		t.Errorf("StoryProgressPercent(0) = %v, want 0", got)
+	}
+	if got := StoryProgressPercent(100); got != 100 {
+		t.Errorf("StoryProgressPercent(100) = %v, want 100", got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package game holds the core idle-game mechanics shared by the SSH and
+// API surfaces: production, upgrade costing, and story progression.
+package game
+
+import "math"
+
+// UpgradeCostGrowth is the multiplier applied to an upgrade's cost for
+// each level already purchased, so later levels of the same upgrade cost
+// more than earlier ones.
+const UpgradeCostGrowth = 1.15
+
+// UpgradeCost returns the cost of buying the next level of an upgrade
+// currently at level, given baseCost for its very first level.
+func UpgradeCost(baseCost float64, level int) float64 {
+	return baseCost * math.Pow(UpgradeCostGrowth, float64(level))
+}
+
+// CumulativeUpgradeCost returns the total cost of buying count consecutive
+// levels of an upgrade starting at level, the sum UpgradeManager's batch
+// buy modes charge a single price for.
+func CumulativeUpgradeCost(baseCost float64, level, count int) float64 {
+	total := 0.0
+	for i := 0; i < count; i++ {
+		total += UpgradeCost(baseCost, level+i)
+	}
+	return total
+}
+
+// UpgradeEffectRate is the keystrokes/sec a single level of an upgrade
+// adds to production, as a fraction of its base cost, so pricier
+// upgrades contribute proportionally more.
+const UpgradeEffectRate = 0.1
+
+// UpgradeEffect returns the keystrokes/sec a single level of an upgrade
+// with baseCost adds to production.
+func UpgradeEffect(baseCost float64) float64 {
+	return baseCost * UpgradeEffectRate
+}
+
+// BaseProductionRate is the keystrokes/sec every player produces before any
+// upgrades, so a brand-new player already earns toward their first
+// purchase instead of being stuck at zero forever.
+const BaseProductionRate = 1.0
+
+// CalculateProductionRate returns the total keystrokes/sec a player
+// produces given the upgrade levels they own, keyed by upgrade type in
+// levels: BaseProductionRate plus level * UpgradeEffect(baseCost) for
+// each upgrade in the catalog. It sums over Upgrades() rather than
+// levels directly, so an entry in levels for an upgrade type the catalog
+// no longer defines can't inflate the result.
+func CalculateProductionRate(levels map[string]int) float64 {
+	rate := BaseProductionRate
+	for _, u := range Upgrades() {
+		rate += float64(levels[u.Type]) * UpgradeEffect(u.BaseCost)
+	}
+	return rate
+}
+
+// MaxAffordableUpgradeLevels returns how many consecutive levels of an
+// upgrade, starting at level, can be bought without the running total
+// exceeding budget - what Max buy mode purchases.
+func MaxAffordableUpgradeLevels(baseCost float64, level int, budget float64) int {
+	count := 0
+	spent := 0.0
+	for {
+		cost := UpgradeCost(baseCost, level+count)
+		if spent+cost > budget {
+			return count
+		}
+		spent += cost
+		count++
+	}
+}
+
+// UpgradeCategory groups upgrades in the catalog by what kind of
+// progress they boost, so a list long enough to scroll can still be
+// browsed by section.
+type UpgradeCategory string
+
+const (
+	UpgradeCategoryProduction UpgradeCategory = "production"
+	UpgradeCategoryAutomation UpgradeCategory = "automation"
+	UpgradeCategoryStory      UpgradeCategory = "story"
+)
+
+// UpgradeDefinition describes a purchasable upgrade: its type key (the
+// string stored in player_upgrades), its display name, the cost of its
+// first level, and the category it's grouped under in the catalog.
+type UpgradeDefinition struct {
+	Type     string
+	Name     string
+	BaseCost float64
+	Category UpgradeCategory
+}
+
+// Upgrades is the catalog of upgrades available for purchase, in the
+// order they should be listed.
+func Upgrades() []UpgradeDefinition {
+	return []UpgradeDefinition{
+		{Type: "faster_typing", Name: "Faster Typing", BaseCost: 10, Category: UpgradeCategoryProduction},
+		{Type: "auto_complete", Name: "Auto Complete", BaseCost: 50, Category: UpgradeCategoryProduction},
+		{Type: "syntax_highlighting", Name: "Syntax Highlighting", BaseCost: 200, Category: UpgradeCategoryProduction},
+		{Type: "linter", Name: "Linter", BaseCost: 750, Category: UpgradeCategoryProduction},
+		{Type: "code_review_bot", Name: "Code Review Bot", BaseCost: 2500, Category: UpgradeCategoryAutomation},
+		{Type: "ci_pipeline", Name: "CI Pipeline", BaseCost: 8000, Category: UpgradeCategoryAutomation},
+		{Type: "ai_pair_programmer", Name: "AI Pair Programmer", BaseCost: 25000, Category: UpgradeCategoryAutomation},
+	}
+}
+
+// BuyMode controls how many levels of an upgrade a single purchase buys
+// at once.
+type BuyMode int
+
+const (
+	BuyModeX10 BuyMode = iota
+	BuyModeX25
+	BuyModeMax
+)
+
+// String implements fmt.Stringer.
+func (m BuyMode) String() string {
+	switch m {
+	case BuyModeX10:
+		return "x10"
+	case BuyModeX25:
+		return "x25"
+	case BuyModeMax:
+		return "Max"
+	default:
+		return "x10"
+	}
+}
+
+// NextBuyMode cycles m to the next buy mode, wrapping from Max back to
+// x10, for a keybinding that steps through them.
+func NextBuyMode(m BuyMode) BuyMode {
+	switch m {
+	case BuyModeX10:
+		return BuyModeX25
+	case BuyModeX25:
+		return BuyModeMax
+	default:
+		return BuyModeX10
+	}
+}
+
+// UpgradeManager batches upgrade purchases according to a BuyMode,
+// computing cumulative cost up front so a single purchase can apply many
+// levels at once instead of one at a time. Its costing math is a pure
+// function of an upgrade's base cost and current level, so it holds no
+// state of its own today; it exists as the seam a future upgrade catalog
+// (per-type base costs, owned levels) can be threaded through without
+// changing every caller of Quote and Purchase.
+type UpgradeManager struct{}
+
+// NewUpgradeManager builds an UpgradeManager.
+func NewUpgradeManager() *UpgradeManager {
+	return &UpgradeManager{}
+}
+
+// Quote computes how many levels mode buys for an upgrade at level with
+// baseCost, and their total cost, capped so it never quotes more levels
+// than budget can afford.
+func (m *UpgradeManager) Quote(baseCost float64, level int, mode BuyMode, budget float64) (levels int, cost float64) {
+	if mode == BuyModeMax {
+		levels = MaxAffordableUpgradeLevels(baseCost, level, budget)
+		return levels, CumulativeUpgradeCost(baseCost, level, levels)
+	}
+
+	switch mode {
+	case BuyModeX25:
+		levels = 25
+	default:
+		levels = 10
+	}
+
+	cost = CumulativeUpgradeCost(baseCost, level, levels)
+	if cost <= budget {
+		return levels, cost
+	}
+
+	levels = MaxAffordableUpgradeLevels(baseCost, level, budget)
+	return levels, CumulativeUpgradeCost(baseCost, level, levels)
+}
+
+// Purchase quotes a batch with Quote and returns the upgrade's new level
+// and the total to charge for it; ok is false if budget couldn't afford
+// even one level, in which case level and cost are unchanged.
+func (m *UpgradeManager) Purchase(baseCost float64, level int, mode BuyMode, budget float64) (newLevel int, cost float64, ok bool) {
+	levels, cost := m.Quote(baseCost, level, mode, budget)
+	if levels == 0 {
+		return level, 0, false
+	}
+	return level + levels, cost, true
+}
@@ -0,0 +1,113 @@
+package game
+
+import "testing"
+
+func TestUpgradeCostGrowsWithLevel(t *testing.T) {
+	base := UpgradeCost(10, 0)
+	next := UpgradeCost(10, 1)
+	if next <= base {
+		t.Errorf("UpgradeCost(10, 1) = %v, want it to be greater than UpgradeCost(10, 0) = %v", next, base)
+	}
+}
+
+func TestCumulativeUpgradeCostSumsIndividualLevels(t *testing.T) {
+	want := UpgradeCost(10, 0) + UpgradeCost(10, 1) + UpgradeCost(10, 2)
+	if got := CumulativeUpgradeCost(10, 0, 3); got != want {
+		t.Errorf("CumulativeUpgradeCost(10, 0, 3) = %v, want %v", got, want)
+	}
+}
+
+func TestUpgradeEffectScalesWithBaseCost(t *testing.T) {
+	cheap := UpgradeEffect(10)
+	pricey := UpgradeEffect(100)
+	if pricey <= cheap {
+		t.Errorf("UpgradeEffect(100) = %v, want it to be greater than UpgradeEffect(10) = %v", pricey, cheap)
+	}
+	if want := 10 * UpgradeEffectRate; cheap != want {
+		t.Errorf("UpgradeEffect(10) = %v, want %v", cheap, want)
+	}
+}
+
+func TestMaxAffordableUpgradeLevelsStopsAtBudget(t *testing.T) {
+	got := MaxAffordableUpgradeLevels(10, 0, 35)
+	if got != 3 {
+		t.Errorf("MaxAffordableUpgradeLevels(10, 0, 35) = %d, want 3", got)
+	}
+	if CumulativeUpgradeCost(10, 0, got) > 35 {
+		t.Errorf("MaxAffordableUpgradeLevels(10, 0, 35) = %d costs more than the budget", got)
+	}
+	if CumulativeUpgradeCost(10, 0, got+1) <= 35 {
+		t.Errorf("MaxAffordableUpgradeLevels(10, 0, 35) = %d, want the next level to exceed the budget", got)
+	}
+}
+
+func TestNextBuyModeCyclesAndWraps(t *testing.T) {
+	if got := NextBuyMode(BuyModeX10); got != BuyModeX25 {
+		t.Errorf("NextBuyMode(BuyModeX10) = %v, want BuyModeX25", got)
+	}
+	if got := NextBuyMode(BuyModeX25); got != BuyModeMax {
+		t.Errorf("NextBuyMode(BuyModeX25) = %v, want BuyModeMax", got)
+	}
+	if got := NextBuyMode(BuyModeMax); got != BuyModeX10 {
+		t.Errorf("NextBuyMode(BuyModeMax) = %v, want it to wrap to BuyModeX10", got)
+	}
+}
+
+func TestUpgradeManagerQuoteX10(t *testing.T) {
+	m := NewUpgradeManager()
+	levels, cost := m.Quote(10, 0, BuyModeX10, 1000)
+	if levels != 10 {
+		t.Errorf("Quote() levels = %d, want 10", levels)
+	}
+	if want := CumulativeUpgradeCost(10, 0, 10); cost != want {
+		t.Errorf("Quote() cost = %v, want %v", cost, want)
+	}
+}
+
+func TestUpgradeManagerQuoteCapsToBudget(t *testing.T) {
+	m := NewUpgradeManager()
+	budget := CumulativeUpgradeCost(10, 0, 3)
+	levels, cost := m.Quote(10, 0, BuyModeX25, budget)
+	if levels != 3 {
+		t.Errorf("Quote() levels = %d, want 3 (capped by budget)", levels)
+	}
+	if cost > budget {
+		t.Errorf("Quote() cost = %v, want it to not exceed budget %v", cost, budget)
+	}
+}
+
+func TestUpgradeManagerQuoteMax(t *testing.T) {
+	m := NewUpgradeManager()
+	levels, cost := m.Quote(10, 0, BuyModeMax, 35)
+	if levels != 3 {
+		t.Errorf("Quote() levels = %d, want 3", levels)
+	}
+	if want := CumulativeUpgradeCost(10, 0, 3); cost != want {
+		t.Errorf("Quote() cost = %v, want %v", cost, want)
+	}
+}
+
+func TestUpgradeManagerPurchaseAdvancesLevel(t *testing.T) {
+	m := NewUpgradeManager()
+	newLevel, cost, ok := m.Purchase(10, 5, BuyModeX10, 1000)
+	if !ok {
+		t.Fatal("Purchase() ok = false, want true")
+	}
+	if newLevel != 15 {
+		t.Errorf("Purchase() newLevel = %d, want 15", newLevel)
+	}
+	if want := CumulativeUpgradeCost(10, 5, 10); cost != want {
+		t.Errorf("Purchase() cost = %v, want %v", cost, want)
+	}
+}
+
+func TestUpgradeManagerPurchaseFailsWithNoBudget(t *testing.T) {
+	m := NewUpgradeManager()
+	newLevel, cost, ok := m.Purchase(10, 0, BuyModeX10, 1)
+	if ok {
+		t.Fatal("Purchase() ok = true, want false when budget can't afford a single level")
+	}
+	if newLevel != 0 || cost != 0 {
+		t.Errorf("Purchase() = (%d, %v), want (0, 0) on failure", newLevel, cost)
+	}
+}
@@ -0,0 +1,71 @@
+package ssh
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// activityThrottle bounds how often a single session writes LastActive to
+// the database, so a player holding a key down doesn't turn every
+// keystroke into a write.
+const activityThrottle = 10 * time.Second
+
+// activityMiddleware stamps a player's LastActive time whenever their
+// session shows real input (a keystroke, a resize), so idle timeouts and
+// playtime stats reflect what they're actually doing rather than just
+// when they connected.
+func activityMiddleware(database db.Database, logger *slog.Logger) wish.Middleware {
+	return func(next cssh.Handler) cssh.Handler {
+		return func(sess cssh.Session) {
+			player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+			if player == nil {
+				next(sess)
+				return
+			}
+
+			next(&activitySession{Session: sess, database: database, logger: logger, playerID: player.ID})
+		}
+	}
+}
+
+// activitySession wraps a cssh.Session, marking its player active on every
+// read that reaches it, which covers both keystrokes and the PTY resize
+// events wish/bubbletea turns into reads under the hood.
+type activitySession struct {
+	cssh.Session
+	database db.Database
+	logger   *slog.Logger
+	playerID string
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func (s *activitySession) Read(p []byte) (int, error) {
+	n, err := s.Session.Read(p)
+	if n > 0 {
+		s.markActive()
+	}
+	return n, err
+}
+
+func (s *activitySession) markActive() {
+	s.mu.Lock()
+	now := time.Now()
+	if now.Sub(s.last) < activityThrottle {
+		s.mu.Unlock()
+		return
+	}
+	s.last = now
+	s.mu.Unlock()
+
+	if err := s.database.UpdateLastActive(s.playerID); err != nil {
+		s.logger.Error("failed to update last active time", "player_id", s.playerID, "error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package ssh
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestActivitySessionMarksPlayerActiveOnRead(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	s := &activitySession{
+		Session:  nil,
+		database: database,
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		playerID: player.ID,
+	}
+
+	s.markActive()
+
+	got, err := database.GetPlayer(player.ID)
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if got.LastActive.IsZero() {
+		t.Error("LastActive is zero, want a timestamp after markActive")
+	}
+}
+
+func TestActivitySessionThrottlesRepeatedActivity(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	s := &activitySession{
+		database: database,
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		playerID: player.ID,
+	}
+
+	s.markActive()
+	first, err := database.GetPlayer(player.ID)
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+
+	s.markActive()
+	second, err := database.GetPlayer(player.ID)
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+
+	if !first.LastActive.Equal(second.LastActive) {
+		t.Errorf("LastActive changed from %v to %v within the throttle window", first.LastActive, second.LastActive)
+	}
+}
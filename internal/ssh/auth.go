@@ -0,0 +1,116 @@
+package ssh
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// ErrUnknownKey is returned by Authenticate when no player is registered
+// under the presented public key yet. Callers should route this to a
+// registration flow rather than treating it as a failed login.
+var ErrUnknownKey = errors.New("no player registered with this key")
+
+// ErrUsernameTaken is returned by Register when another player has already
+// claimed username, so the caller can ask for a different one instead of
+// silently being handed someone else's account.
+var ErrUsernameTaken = errors.New("username is already registered")
+
+// Authenticator resolves the player behind an incoming SSH connection, so
+// operators can plug in their own identity source - an external command,
+// an OAuth device-flow token, a password-less deny-all for a maintenance
+// window - without forking publicKeyAuthHandler. Authenticate returning an
+// error fails the connection; the error's text is what gets logged and, in
+// most cases, shown to the rejected client.
+type Authenticator interface {
+	Authenticate(username string, key ssh.PublicKey) (*db.Player, error)
+}
+
+// KeyAuthenticator is the default Authenticator. It resolves players by
+// the key they present rather than the username they typed (see
+// Authenticate), registering a new player under Realm the first time a
+// key is seen.
+type KeyAuthenticator struct {
+	Database db.Database
+	Realm    string
+}
+
+// Authenticate implements Authenticator.
+func (a KeyAuthenticator) Authenticate(username string, key ssh.PublicKey) (*db.Player, error) {
+	player, err := Authenticate(a.Database, username, key)
+	if errors.Is(err, ErrUnknownKey) {
+		player, err = Register(a.Database, username, a.Realm, key)
+	}
+	return player, err
+}
+
+// Authenticate resolves the player behind an incoming SSH connection by the
+// key they presented, not the username they typed, so the same player gets
+// their own save no matter what username they connect as next time. An
+// unrecognized key returns ErrUnknownKey so the caller can run Register
+// instead. If the presented username differs from the one on file, it's
+// treated as a rename: Authenticate best-effort updates the stored username
+// to match, silently keeping the old one if that name is already taken by
+// someone else.
+func Authenticate(database db.Database, username string, key ssh.PublicKey) (*db.Player, error) {
+	player, err := database.GetPlayerByPublicKey(authorizedKeyString(key))
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, ErrUnknownKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if player.Username != username {
+		if err := database.RenamePlayer(player.ID, username); err == nil {
+			player.Username = username
+		}
+	}
+
+	return player, nil
+}
+
+// Register claims username for a new player bound to key, for use once a
+// caller has confirmed with the connecting client that this is the name
+// and key they want to register. A key that's already registered is
+// handed back its existing player rather than creating a duplicate, so
+// Register is safe to call as a fallback after ErrUnknownKey. It fails
+// with ErrUsernameTaken if another player has already claimed username.
+func Register(database db.Database, username, realm string, key ssh.PublicKey) (*db.Player, error) {
+	id, err := newPlayerID()
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := database.CreateOrGetPlayerByKey(id, username, authorizedKeyString(key), realm)
+	if errors.Is(err, db.ErrAlreadyExists) {
+		return nil, ErrUsernameTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+// authorizedKeyString renders key in the same "ssh-ed25519 AAAA..." format
+// players' public keys are stored in, so a presented key can be compared
+// against the stored one with a plain string equality check.
+func authorizedKeyString(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// newPlayerID generates a random ID for a player registering for the first
+// time.
+func newPlayerID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
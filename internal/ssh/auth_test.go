@@ -0,0 +1,183 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func newTestPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	signer, err := ssh.NewSignerFromSigner(priv)
+	if err != nil {
+		t.Fatalf("ssh.NewSignerFromSigner() error = %v", err)
+	}
+	return signer.PublicKey()
+}
+
+func newTestDB(t *testing.T) db.Database {
+	t.Helper()
+
+	sdb, err := db.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+	return sdb
+}
+
+func TestAuthenticateRejectsUnknownKey(t *testing.T) {
+	database := newTestDB(t)
+
+	_, err := Authenticate(database, "monkey", newTestPublicKey(t))
+	if err != ErrUnknownKey {
+		t.Fatalf("Authenticate() error = %v, want %v", err, ErrUnknownKey)
+	}
+}
+
+func TestAuthenticateAcceptsMatchingKeyAfterRegistration(t *testing.T) {
+	database := newTestDB(t)
+	key := newTestPublicKey(t)
+
+	if _, err := Register(database, "monkey", "main", key); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	player, err := Authenticate(database, "monkey", key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if player.Username != "monkey" {
+		t.Errorf("Username = %q, want %q", player.Username, "monkey")
+	}
+}
+
+func TestAuthenticateResolvesSamePlayerUnderNewUsername(t *testing.T) {
+	database := newTestDB(t)
+	key := newTestPublicKey(t)
+
+	registered, err := Register(database, "monkey", "main", key)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	player, err := Authenticate(database, "gorilla", key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if player.ID != registered.ID {
+		t.Errorf("ID = %q, want %q", player.ID, registered.ID)
+	}
+	if player.Username != "gorilla" {
+		t.Errorf("Username = %q, want %q", player.Username, "gorilla")
+	}
+}
+
+func TestAuthenticateKeepsOldUsernameIfNewOneIsTaken(t *testing.T) {
+	database := newTestDB(t)
+
+	if _, err := Register(database, "gorilla", "main", newTestPublicKey(t)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	key := newTestPublicKey(t)
+	if _, err := Register(database, "monkey", "main", key); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	player, err := Authenticate(database, "gorilla", key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if player.Username != "monkey" {
+		t.Errorf("Username = %q, want %q", player.Username, "monkey")
+	}
+}
+
+func TestRegisterClaimsNewUsername(t *testing.T) {
+	database := newTestDB(t)
+	key := newTestPublicKey(t)
+
+	player, err := Register(database, "monkey", "main", key)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if player.Username != "monkey" {
+		t.Errorf("Username = %q, want %q", player.Username, "monkey")
+	}
+	if player.PublicKey != authorizedKeyString(key) {
+		t.Errorf("PublicKey = %q, want %q", player.PublicKey, authorizedKeyString(key))
+	}
+}
+
+func TestRegisterRejectsUsernameAlreadyTaken(t *testing.T) {
+	database := newTestDB(t)
+
+	if _, err := Register(database, "monkey", "main", newTestPublicKey(t)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	_, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != ErrUsernameTaken {
+		t.Fatalf("Register() error = %v, want %v", err, ErrUsernameTaken)
+	}
+}
+
+func TestKeyAuthenticatorRegistersUnknownKeyOnFirstConnection(t *testing.T) {
+	database := newTestDB(t)
+	auth := KeyAuthenticator{Database: database, Realm: "main"}
+
+	player, err := auth.Authenticate("monkey", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if player.Username != "monkey" || player.Realm != "main" {
+		t.Errorf("player = %+v, want a new monkey in realm main", player)
+	}
+}
+
+func TestKeyAuthenticatorResolvesRegisteredKeyWithoutReregistering(t *testing.T) {
+	database := newTestDB(t)
+	auth := KeyAuthenticator{Database: database, Realm: "main"}
+	key := newTestPublicKey(t)
+
+	first, err := auth.Authenticate("monkey", key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	second, err := auth.Authenticate("monkey", key)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("ID = %q, want %q", second.ID, first.ID)
+	}
+}
+
+func TestRegisterReturnsExistingPlayerForAlreadyRegisteredKey(t *testing.T) {
+	database := newTestDB(t)
+	key := newTestPublicKey(t)
+
+	first, err := Register(database, "monkey", "main", key)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	second, err := Register(database, "monkey", "main", key)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("ID = %q, want %q", second.ID, first.ID)
+	}
+}
@@ -0,0 +1,115 @@
+package ssh
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// Ban kinds recognized by AddConnectionBan and the ban list file.
+const (
+	BanKindCIDR = "cidr"
+	BanKindKey  = "key"
+)
+
+// ConnectionFilter rejects incoming connections that match a banned IP
+// range or public key fingerprint, checked before authentication so a
+// banned client never gets the chance to present credentials.
+type ConnectionFilter struct {
+	db   db.Database
+	file []*db.ConnectionBan
+}
+
+// NewConnectionFilter loads bans from cfg.BanListFile, if set, to check
+// alongside the ones stored in database through the admin API.
+func NewConnectionFilter(database db.Database, cfg Config) (*ConnectionFilter, error) {
+	var file []*db.ConnectionBan
+	if cfg.BanListFile != "" {
+		var err error
+		file, err = parseBanListFile(cfg.BanListFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ConnectionFilter{db: database, file: file}, nil
+}
+
+// Check reports whether ip or key is banned, and why. Either the file-based
+// list or the database-backed one is enough to block a connection.
+func (f *ConnectionFilter) Check(ip string, key ssh.PublicKey) (banned bool, reason string, err error) {
+	stored, err := f.db.ListConnectionBans()
+	if err != nil {
+		return false, "", err
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+	for _, bans := range [][]*db.ConnectionBan{f.file, stored} {
+		for _, ban := range bans {
+			switch ban.Kind {
+			case BanKindCIDR:
+				if cidrContains(ban.Value, ip) {
+					return true, ban.Reason, nil
+				}
+			case BanKindKey:
+				if ban.Value == fingerprint {
+					return true, ban.Reason, nil
+				}
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+func cidrContains(cidr, ip string) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	return network.Contains(parsed)
+}
+
+// parseBanListFile reads a ban list file, one entry per line in the form
+// "<cidr|key> <value> [reason...]". Blank lines and lines starting with #
+// are ignored.
+func parseBanListFile(path string) ([]*db.ConnectionBan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ban list %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var bans []*db.ConnectionBan
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid ban list entry %q in %s", line, path)
+		}
+		ban := &db.ConnectionBan{Kind: fields[0], Value: fields[1]}
+		if len(fields) == 3 {
+			ban.Reason = fields[2]
+		}
+		bans = append(bans, ban)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ban list %s: %w", path, err)
+	}
+
+	return bans, nil
+}
@@ -0,0 +1,90 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestConnectionFilterBlocksBannedCIDRFromDatabase(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.AddConnectionBan("203.0.113.0/24", BanKindCIDR, "spam"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+
+	filter, err := NewConnectionFilter(database, Config{})
+	if err != nil {
+		t.Fatalf("NewConnectionFilter() error = %v", err)
+	}
+
+	banned, reason, err := filter.Check("203.0.113.42", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !banned || reason != "spam" {
+		t.Errorf("Check() = (%v, %q), want (true, %q)", banned, reason, "spam")
+	}
+}
+
+func TestConnectionFilterBlocksBannedKeyFingerprint(t *testing.T) {
+	database := newTestDB(t)
+	key := newTestPublicKey(t)
+	fingerprint := ssh.FingerprintSHA256(key)
+	if err := database.AddConnectionBan(fingerprint, BanKindKey, "compromised"); err != nil {
+		t.Fatalf("AddConnectionBan() error = %v", err)
+	}
+
+	filter, err := NewConnectionFilter(database, Config{})
+	if err != nil {
+		t.Fatalf("NewConnectionFilter() error = %v", err)
+	}
+
+	banned, reason, err := filter.Check("198.51.100.1", key)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !banned || reason != "compromised" {
+		t.Errorf("Check() = (%v, %q), want (true, %q)", banned, reason, "compromised")
+	}
+}
+
+func TestConnectionFilterAllowsUnbannedConnection(t *testing.T) {
+	database := newTestDB(t)
+
+	filter, err := NewConnectionFilter(database, Config{})
+	if err != nil {
+		t.Fatalf("NewConnectionFilter() error = %v", err)
+	}
+
+	banned, _, err := filter.Check("198.51.100.1", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if banned {
+		t.Error("Check() = true, want false for an unbanned IP and key")
+	}
+}
+
+func TestConnectionFilterLoadsBanListFile(t *testing.T) {
+	database := newTestDB(t)
+	path := filepath.Join(t.TempDir(), "bans.txt")
+	contents := "# comment\ncidr 192.0.2.0/24 abuse\n\nkey SHA256:deadbeef leaked\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	filter, err := NewConnectionFilter(database, Config{BanListFile: path})
+	if err != nil {
+		t.Fatalf("NewConnectionFilter() error = %v", err)
+	}
+
+	banned, reason, err := filter.Check("192.0.2.5", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !banned || reason != "abuse" {
+		t.Errorf("Check() = (%v, %q), want (true, %q)", banned, reason, "abuse")
+	}
+}
@@ -0,0 +1,135 @@
+package ssh
+
+import (
+	"fmt"
+	"sync"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+// CapacityConfig bounds how many players can be playing at once, and how
+// many more are allowed to wait in line for a slot to free up.
+type CapacityConfig struct {
+	// MaxSessions is the number of interactive sessions allowed to run at
+	// once. Zero means unlimited.
+	MaxSessions int
+
+	// MaxQueueSize is how many connections beyond MaxSessions are held in
+	// the waiting queue before new ones are turned away outright. Ignored
+	// if MaxSessions is zero.
+	MaxQueueSize int
+}
+
+// waiter is one connection parked in the CapacityQueue, waiting for a slot.
+type waiter struct {
+	admitted chan struct{}
+}
+
+// CapacityQueue admits interactive sessions up to a fixed limit and holds
+// the rest in a FIFO queue, admitting the next waiter whenever a slot frees
+// up. Exec and scp requests are never subject to it; only the long-lived
+// game session is.
+type CapacityQueue struct {
+	cfg CapacityConfig
+
+	mu      sync.Mutex
+	active  int
+	waiting []*waiter
+}
+
+// NewCapacityQueue builds a CapacityQueue from cfg.
+func NewCapacityQueue(cfg CapacityConfig) *CapacityQueue {
+	return &CapacityQueue{cfg: cfg}
+}
+
+// Admit blocks until a slot is free for sess, writing a "server full"
+// message and the caller's queue position to sess in the meantime. It
+// returns a release func to call once the caller is done with its slot,
+// and false if sess disconnected before a slot became available or the
+// queue was already full.
+func (q *CapacityQueue) Admit(sess cssh.Session) (release func(), ok bool) {
+	if q.cfg.MaxSessions <= 0 {
+		return func() {}, true
+	}
+
+	q.mu.Lock()
+	if q.active < q.cfg.MaxSessions {
+		q.active++
+		q.mu.Unlock()
+		return func() { q.release() }, true
+	}
+	if len(q.waiting) >= q.cfg.MaxQueueSize {
+		q.mu.Unlock()
+		fmt.Fprintln(sess, "Server is full and the waiting queue is too, please try again later.")
+		return nil, false
+	}
+
+	w := &waiter{admitted: make(chan struct{})}
+	q.waiting = append(q.waiting, w)
+	position := len(q.waiting)
+	q.mu.Unlock()
+
+	fmt.Fprintf(sess, "Server is full, you are #%d in the queue. Hold tight...\n", position)
+
+	select {
+	case <-w.admitted:
+		fmt.Fprintln(sess, "A slot opened up, welcome in!")
+		return func() { q.release() }, true
+	case <-sess.Context().Done():
+		q.abandon(w)
+		return nil, false
+	}
+}
+
+// release frees the caller's slot and admits the next waiter, if any.
+func (q *CapacityQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.active--
+		return
+	}
+
+	next := q.waiting[0]
+	q.waiting = q.waiting[1:]
+	close(next.admitted)
+}
+
+// abandon removes w from the queue after its session disconnected while
+// still waiting, so it doesn't take up a queue slot forever.
+func (q *CapacityQueue) abandon(w *waiter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, other := range q.waiting {
+		if other == w {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			return
+		}
+	}
+}
+
+// capacityMiddleware holds interactive sessions in queue until a slot is
+// free once the server is at MaxSessions, rather than letting wish accept
+// connections it has no capacity to serve. Exec and scp requests, which
+// are brief and don't occupy a game slot, skip the queue entirely.
+func capacityMiddleware(queue *CapacityQueue) wish.Middleware {
+	return func(next cssh.Handler) cssh.Handler {
+		return func(sess cssh.Session) {
+			if len(sess.Command()) != 0 {
+				next(sess)
+				return
+			}
+
+			release, ok := queue.Admit(sess)
+			if !ok {
+				return
+			}
+			defer release()
+
+			next(sess)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+)
+
+// fakeCapacityContext is a minimal cssh.Context stand-in whose Done channel
+// is driven by a real context.Context, so CapacityQueue.Admit can react to
+// a session disconnecting while it waits in the queue.
+type fakeCapacityContext struct {
+	cssh.Context
+	ctx context.Context
+}
+
+func (c *fakeCapacityContext) Done() <-chan struct{} { return c.ctx.Done() }
+func (c *fakeCapacityContext) Err() error            { return c.ctx.Err() }
+
+// fakeCapacitySession is a minimal cssh.Session stand-in exposing only the
+// Context and Write methods CapacityQueue.Admit needs.
+type fakeCapacitySession struct {
+	cssh.Session
+	ctx cssh.Context
+	out bytes.Buffer
+}
+
+func (s *fakeCapacitySession) Context() cssh.Context       { return s.ctx }
+func (s *fakeCapacitySession) Write(p []byte) (int, error) { return s.out.Write(p) }
+
+func newFakeCapacitySession() (*fakeCapacitySession, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &fakeCapacitySession{ctx: &fakeCapacityContext{ctx: ctx}}, cancel
+}
+
+func TestCapacityQueueUnlimitedWhenMaxSessionsZero(t *testing.T) {
+	q := NewCapacityQueue(CapacityConfig{})
+	sess, cancel := newFakeCapacitySession()
+	defer cancel()
+
+	_, ok := q.Admit(sess)
+	if !ok {
+		t.Fatal("Admit() ok = false, want true with no configured limit")
+	}
+}
+
+func TestCapacityQueueAdmitsImmediatelyUnderLimit(t *testing.T) {
+	q := NewCapacityQueue(CapacityConfig{MaxSessions: 2})
+	sess, cancel := newFakeCapacitySession()
+	defer cancel()
+
+	release, ok := q.Admit(sess)
+	if !ok {
+		t.Fatal("Admit() ok = false, want true under the limit")
+	}
+	defer release()
+
+	if sess.out.Len() != 0 {
+		t.Errorf("Admit() wrote %q, want no queue message when a slot was free", sess.out.String())
+	}
+}
+
+func TestCapacityQueueAdmitsWaiterOnceASlotReleases(t *testing.T) {
+	q := NewCapacityQueue(CapacityConfig{MaxSessions: 1, MaxQueueSize: 1})
+	first, cancelFirst := newFakeCapacitySession()
+	defer cancelFirst()
+	second, cancelSecond := newFakeCapacitySession()
+	defer cancelSecond()
+
+	release, ok := q.Admit(first)
+	if !ok {
+		t.Fatal("Admit() ok = false for the first caller, want true")
+	}
+
+	done := make(chan bool, 1)
+	go func() {
+		_, ok := q.Admit(second)
+		done <- ok
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if !strings.Contains(second.out.String(), "#1") {
+		t.Errorf("queued caller was not told its position, wrote %q", second.out.String())
+	}
+
+	release()
+
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Error("Admit() ok = false for the queued caller after a slot freed up, want true")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Admit() for the queued caller never returned after a slot freed up")
+	}
+}
+
+func TestCapacityQueueRejectsWhenQueueIsFull(t *testing.T) {
+	q := NewCapacityQueue(CapacityConfig{MaxSessions: 1, MaxQueueSize: 0})
+	first, cancelFirst := newFakeCapacitySession()
+	defer cancelFirst()
+	second, cancelSecond := newFakeCapacitySession()
+	defer cancelSecond()
+
+	if _, ok := q.Admit(first); !ok {
+		t.Fatal("Admit() ok = false for the first caller, want true")
+	}
+
+	if _, ok := q.Admit(second); ok {
+		t.Fatal("Admit() ok = true for a caller with no room in the queue, want false")
+	}
+	if !strings.Contains(second.out.String(), "full") {
+		t.Errorf("rejected caller was not told the server is full, wrote %q", second.out.String())
+	}
+}
+
+func TestCapacityQueueAbandonsWaiterOnDisconnect(t *testing.T) {
+	q := NewCapacityQueue(CapacityConfig{MaxSessions: 1, MaxQueueSize: 2})
+	first, cancelFirst := newFakeCapacitySession()
+	defer cancelFirst()
+
+	if _, ok := q.Admit(first); !ok {
+		t.Fatal("Admit() ok = false for the first caller, want true")
+	}
+
+	waiter, cancelWaiter := newFakeCapacitySession()
+	done := make(chan bool, 1)
+	go func() {
+		_, ok := q.Admit(waiter)
+		done <- ok
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancelWaiter()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("Admit() ok = true for a disconnected waiter, want false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Admit() never returned after the waiter's session disconnected")
+	}
+
+	q.mu.Lock()
+	waiting := len(q.waiting)
+	q.mu.Unlock()
+	if waiting != 0 {
+		t.Errorf("waiting queue len = %d, want 0 after the waiter was abandoned", waiting)
+	}
+}
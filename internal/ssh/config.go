@@ -0,0 +1,104 @@
+package ssh
+
+import (
+	"os"
+
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+// Config holds the settings for the SSH gateway.
+type Config struct {
+	// HostKeyFile is the path to the server's persistent Ed25519 host key.
+	// It's generated on first run if the file doesn't exist yet, so clients
+	// see the same fingerprint across restarts.
+	HostKeyFile string
+
+	// MOTD is shown to every client before the game starts, e.g. to
+	// announce maintenance windows or in-game events. Ignored if MOTDFile
+	// is set.
+	MOTD string
+	// MOTDFile, if set, is read fresh on every connection, so operators can
+	// update the banner without restarting the server.
+	MOTDFile string
+
+	// BanListFile, if set, is loaded on startup and merged with bans added
+	// through the admin API, so operators can check a baseline ban list
+	// into version control alongside ones added at runtime.
+	BanListFile string
+
+	// RateLimit controls per-IP and global throttling of new connections.
+	RateLimit RateLimitConfig
+
+	// LogDir, if set, is where structured session logs (auth attempts, key
+	// fingerprints, commands, and errors) are written as rotating files.
+	// If empty, the same events are logged to stdout instead.
+	LogDir string
+
+	// Capacity bounds how many players can be playing at once, holding the
+	// rest in a waiting queue instead of overloading the server.
+	Capacity CapacityConfig
+
+	// ProxyProtocol controls PROXY protocol v1/v2 parsing on the listener,
+	// for deployments that sit behind a load balancer rather than taking
+	// connections directly.
+	ProxyProtocol ProxyProtocolConfig
+
+	// AdminFingerprints lists the SSH key fingerprints (as recorded in the
+	// auth log and printed by `ssh-keygen -lf`) allowed to run admin-only
+	// exec commands such as status. Leave empty to disable them entirely.
+	AdminFingerprints []string
+
+	// AdminTOTP maps an admin fingerprint from AdminFingerprints to their
+	// TOTP secret (base32, as provisioned into an authenticator app). An
+	// admin with an entry here is prompted for their current code before
+	// an admin command runs; one without an entry can use their key
+	// alone, so second-factor enforcement can be rolled out per admin.
+	AdminTOTP map[string]string
+
+	// Theme selects the color palette the game view renders with for
+	// every player. Defaults to ui.ThemeDefault when empty.
+	Theme ui.Theme
+
+	// Locale selects the language the game view's text renders in for
+	// every player. Defaults to ui.LocaleEN when empty or unrecognized.
+	Locale ui.Locale
+
+	// KeyBindings remaps the game view's keybindings away from their
+	// defaults (see ui.KeyBindingOverrides), for operators whose players
+	// expect different keys than the ones the game ships with. An action
+	// left out keeps its default keys.
+	KeyBindings ui.KeyBindingOverrides
+}
+
+// ProxyProtocolConfig controls whether the SSH listener expects a PROXY
+// protocol header in front of each connection, as HAProxy and most cloud
+// network load balancers send, so logging, rate limiting, and bans see the
+// real client IP instead of the balancer's.
+type ProxyProtocolConfig struct {
+	// Enabled turns on PROXY header parsing. Leave it off when the listener
+	// is reachable directly, since a raw client connecting to an Enabled
+	// listener outside TrustedCIDRs is dropped rather than served.
+	Enabled bool
+
+	// TrustedCIDRs is the set of upstream addresses allowed to send a PROXY
+	// header, as individual IPs or CIDR ranges (e.g. the load balancer's
+	// subnet). A connection from outside this set is dropped instead of
+	// trusted with whatever client address it claims. Required when Enabled
+	// is true.
+	TrustedCIDRs []string
+}
+
+// Banner returns the message-of-the-day to show a connecting client. It
+// reads MOTDFile on every call when set, falling back to the static MOTD
+// text, so an empty Config produces an empty banner rather than an error.
+func (c Config) Banner() (string, error) {
+	if c.MOTDFile == "" {
+		return c.MOTD, nil
+	}
+
+	raw, err := os.ReadFile(c.MOTDFile)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
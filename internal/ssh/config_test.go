@@ -0,0 +1,45 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigBannerReturnsInlineMOTD(t *testing.T) {
+	cfg := Config{MOTD: "welcome to term idle"}
+
+	banner, err := cfg.Banner()
+	if err != nil {
+		t.Fatalf("Banner() error = %v", err)
+	}
+	if banner != "welcome to term idle" {
+		t.Errorf("Banner() = %q, want %q", banner, "welcome to term idle")
+	}
+}
+
+func TestConfigBannerPrefersMOTDFileOverInline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "motd.txt")
+	if err := os.WriteFile(path, []byte("scheduled maintenance at 2am"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	cfg := Config{MOTD: "inline text", MOTDFile: path}
+
+	banner, err := cfg.Banner()
+	if err != nil {
+		t.Fatalf("Banner() error = %v", err)
+	}
+	if banner != "scheduled maintenance at 2am" {
+		t.Errorf("Banner() = %q, want the file's contents", banner)
+	}
+}
+
+func TestConfigBannerEmptyByDefault(t *testing.T) {
+	banner, err := (Config{}).Banner()
+	if err != nil {
+		t.Fatalf("Banner() error = %v", err)
+	}
+	if banner != "" {
+		t.Errorf("Banner() = %q, want empty", banner)
+	}
+}
@@ -0,0 +1,252 @@
+package ssh
+
+import (
+	"sync"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// engineTick is how often a running Engine accrues production for its
+// player, regardless of whether a connected terminal is rendering, or
+// even connected at all.
+const engineTick = 1 * time.Second
+
+// engineSaveInterval is how often a running Engine persists its state and
+// refreshes the player's leaderboard entry.
+const engineSaveInterval = 10 * time.Second
+
+// kpsHistoryLen is how many production-rate samples a running Engine
+// retains, one per tick, enough for a few minutes of history at
+// engineTick resolution for a progression sparkline.
+const kpsHistoryLen = 180
+
+// Engine runs one player's production simulation in its own goroutine on
+// the server side, independent of the bubbletea render loop. bm.Handler
+// only renders whatever the Engine has already produced; it never drives
+// production itself, so progression, auto-saves, and leaderboard updates
+// keep happening even if the player's terminal stalls.
+type Engine struct {
+	database db.Database
+	player   *db.Player
+
+	mu      sync.Mutex
+	state   db.GameState
+	history []float64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewEngine starts an Engine ticking state forward for player, until Stop
+// is called.
+func NewEngine(database db.Database, player *db.Player, state *db.GameState) *Engine {
+	e := &Engine{
+		database: database,
+		player:   player,
+		state:    *state,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// State returns a copy of the Engine's current game state, safe to render
+// without racing the tick goroutine.
+func (e *Engine) State() db.GameState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.state
+}
+
+// Stop ends the Engine's tick loop, persisting its final state first, and
+// blocks until the goroutine has exited.
+func (e *Engine) Stop() {
+	close(e.stop)
+	<-e.done
+}
+
+func (e *Engine) run() {
+	defer close(e.done)
+
+	// Persist once up front, so a player's existing progress shows up on
+	// the leaderboard as soon as they connect rather than waiting for the
+	// first tick of saveTicker below.
+	e.persist()
+
+	ticker := time.NewTicker(engineTick)
+	defer ticker.Stop()
+
+	saveTicker := time.NewTicker(engineSaveInterval)
+	defer saveTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.tick(engineTick)
+		case <-saveTicker.C:
+			e.persist()
+		case <-e.stop:
+			e.persist()
+			return
+		}
+	}
+}
+
+// tick accrues elapsed worth of production into the Engine's state and
+// records the production rate it ran at as a history sample.
+func (e *Engine) tick(elapsed time.Duration) {
+	e.mu.Lock()
+	e.state.Keystrokes += e.state.ProductionRate * elapsed.Seconds()
+	e.history = append(e.history, e.state.ProductionRate)
+	if len(e.history) > kpsHistoryLen {
+		e.history = e.history[len(e.history)-kpsHistoryLen:]
+	}
+	e.mu.Unlock()
+}
+
+// History returns a copy of the Engine's recent production-rate samples,
+// oldest first, for rendering a short-term progression sparkline.
+func (e *Engine) History() []float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	history := make([]float64, len(e.history))
+	copy(history, e.history)
+	return history
+}
+
+// Save immediately persists the Engine's current state and refreshes the
+// player's leaderboard entry, for a player-triggered save rather than
+// waiting for the next engineSaveInterval tick.
+func (e *Engine) Save() error {
+	state := e.State()
+	if err := e.database.SaveGameState(&state); err != nil {
+		return err
+	}
+	return e.database.UpdateLeaderboard(&db.LeaderboardEntry{
+		PlayerID:   e.player.ID,
+		Realm:      state.Realm,
+		Username:   e.player.Username,
+		Keystrokes: state.Keystrokes,
+		Words:      state.Words,
+		Programs:   state.Programs,
+	})
+}
+
+// Purchase deducts cost from the Engine's Keystrokes and persists the
+// upgrade purchase (which also refreshes the player's leaderboard entry)
+// in a single transaction, so a purchase's cost and its effect always land
+// together. On error, cost is not deducted from the Engine's live state.
+func (e *Engine) Purchase(upgradeType string, level int, cost float64) error {
+	e.mu.Lock()
+	e.state.Keystrokes -= cost
+	state := e.state
+	e.mu.Unlock()
+
+	if err := e.database.PurchaseUpgrade(e.player.ID, upgradeType, level, &state); err != nil {
+		e.mu.Lock()
+		e.state.Keystrokes += cost
+		e.mu.Unlock()
+		return err
+	}
+
+	e.mu.Lock()
+	e.state = state
+	e.mu.Unlock()
+	return nil
+}
+
+// persist saves the Engine's current state and refreshes the player's
+// leaderboard entry. Failures are swallowed rather than surfaced to the
+// player; the next persist, whether on the next interval or Stop, gets
+// another chance.
+func (e *Engine) persist() {
+	_ = e.Save()
+}
+
+// EngineRegistry keeps a player's Engine running for a grace period after
+// they disconnect, so progression, auto-saves, and leaderboard updates
+// carry on uninterrupted through a reconnect instead of stopping the
+// instant the connection drops.
+type EngineRegistry struct {
+	grace time.Duration
+
+	mu      sync.Mutex
+	engines map[string]*retainedEngine
+}
+
+// retainedEngine is one player's running Engine. timer is nil while the
+// player is connected; Release sets it, starting the countdown to
+// stopping the Engine, and Acquire stops it again if they reconnect in
+// time.
+type retainedEngine struct {
+	engine *Engine
+	timer  *time.Timer
+}
+
+// NewEngineRegistry returns an EngineRegistry that stops a disconnected
+// player's Engine after grace.
+func NewEngineRegistry(grace time.Duration) *EngineRegistry {
+	return &EngineRegistry{grace: grace, engines: make(map[string]*retainedEngine)}
+}
+
+// Acquire returns the running Engine for player, starting one from state
+// if none is running yet, and reports whether it had to start one.
+// Reconnecting within a disconnected player's grace period resumes the
+// same Engine, with whatever it has already produced while they were
+// away, rather than starting a new one from whatever was last saved.
+func (r *EngineRegistry) Acquire(database db.Database, player *db.Player, state *db.GameState) (engine *Engine, created bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.engines[player.ID]; ok {
+		if entry.timer != nil {
+			entry.timer.Stop()
+			entry.timer = nil
+		}
+		return entry.engine, false
+	}
+
+	engine = NewEngine(database, player, state)
+	r.engines[player.ID] = &retainedEngine{engine: engine}
+	return engine, true
+}
+
+// Len returns how many Engines are currently running, whether their
+// player is connected or still within the grace period after a
+// disconnect. Each one represents a session whose progress hasn't
+// necessarily hit disk yet, so this doubles as a save-queue depth for
+// operators.
+func (r *EngineRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.engines)
+}
+
+// Release starts playerID's grace period countdown, called once their
+// connection ends. Their Engine keeps ticking and auto-saving until the
+// grace period elapses without a reconnect, at which point it's stopped.
+func (r *EngineRegistry) Release(playerID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.engines[playerID]
+	if !ok {
+		return
+	}
+	entry.timer = time.AfterFunc(r.grace, func() { r.evict(playerID) })
+}
+
+func (r *EngineRegistry) evict(playerID string) {
+	r.mu.Lock()
+	entry, ok := r.engines[playerID]
+	if ok {
+		delete(r.engines, playerID)
+	}
+	r.mu.Unlock()
+
+	if ok {
+		entry.engine.Stop()
+	}
+}
@@ -0,0 +1,243 @@
+package ssh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+func TestEngineAccruesProductionWhileRunning(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 100})
+	t.Cleanup(engine.Stop)
+
+	if state := engine.State(); state.Keystrokes != 0 {
+		t.Fatalf("Keystrokes before any ticks = %v, want 0", state.Keystrokes)
+	}
+
+	engine.tick(time.Second)
+	engine.tick(time.Second)
+
+	if state := engine.State(); state.Keystrokes != 200 {
+		t.Errorf("Keystrokes after two ticks = %v, want 200", state.Keystrokes)
+	}
+}
+
+func TestEngineStopPersistsFinalState(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 50})
+	engine.tick(time.Second)
+	engine.Stop()
+
+	state, err := database.GetGameState("p1", "main")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if state.Keystrokes != 50 {
+		t.Errorf("Keystrokes = %v, want 50", state.Keystrokes)
+	}
+
+	entries, err := database.GetLeaderboard("main", db.SortByKeystrokes, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Keystrokes != 50 {
+		t.Errorf("GetLeaderboard() = %+v, want one entry with 50 keystrokes", entries)
+	}
+}
+
+func TestEngineSavePersistsImmediately(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 50})
+	t.Cleanup(engine.Stop)
+	engine.tick(time.Second)
+
+	if err := engine.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	state, err := database.GetGameState("p1", "main")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if state.Keystrokes != 50 {
+		t.Errorf("Keystrokes = %v, want the 50 accrued before Save() was called", state.Keystrokes)
+	}
+}
+
+func TestEnginePurchaseDeductsCostAndPersists(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", Keystrokes: 100, ProductionRate: game.BaseProductionRate})
+	t.Cleanup(engine.Stop)
+
+	before := engine.State().ProductionRate
+
+	if err := engine.Purchase("faster_typing", 1, 40); err != nil {
+		t.Fatalf("Purchase() error = %v", err)
+	}
+
+	if got := engine.State().Keystrokes; got != 60 {
+		t.Errorf("State().Keystrokes = %v, want 60 after spending 40 of 100", got)
+	}
+	if got := engine.State().ProductionRate; got <= before {
+		t.Errorf("State().ProductionRate = %v after purchase, want more than %v", got, before)
+	}
+
+	state, err := database.GetGameState("p1", "main")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if state.Keystrokes != 60 {
+		t.Errorf("persisted Keystrokes = %v, want 60", state.Keystrokes)
+	}
+	if state.ProductionRate != engine.State().ProductionRate {
+		t.Errorf("persisted ProductionRate = %v, want %v", state.ProductionRate, engine.State().ProductionRate)
+	}
+	if state.UpgradeLevels["faster_typing"] != 1 {
+		t.Errorf("persisted UpgradeLevels[faster_typing] = %d, want 1", state.UpgradeLevels["faster_typing"])
+	}
+}
+
+func TestEngineAddsPlayerToLeaderboardAsSoonAsItStarts(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", Keystrokes: 1000})
+	t.Cleanup(engine.Stop)
+
+	var entries []*db.LeaderboardEntry
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		var err error
+		entries, err = database.GetLeaderboard("main", db.SortByKeystrokes, 0, 10, 0)
+		if err != nil {
+			t.Fatalf("GetLeaderboard() error = %v", err)
+		}
+		if len(entries) > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if len(entries) != 1 || entries[0].Keystrokes != 1000 {
+		t.Errorf("GetLeaderboard() = %+v, want an entry with the player's starting keystrokes right away", entries)
+	}
+}
+
+func TestEngineHistoryRecordsOneSamplePerTick(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 5})
+	t.Cleanup(engine.Stop)
+
+	engine.tick(time.Second)
+	engine.tick(time.Second)
+	engine.tick(time.Second)
+
+	history := engine.History()
+	if len(history) != 3 {
+		t.Fatalf("History() = %v, want 3 samples", history)
+	}
+	for _, sample := range history {
+		if sample != 5 {
+			t.Errorf("History() sample = %v, want 5", sample)
+		}
+	}
+}
+
+func TestEngineHistoryCapsAtKpsHistoryLen(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	engine := NewEngine(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 1})
+	t.Cleanup(engine.Stop)
+
+	for i := 0; i < kpsHistoryLen+20; i++ {
+		engine.tick(time.Second)
+	}
+
+	if got := len(engine.History()); got != kpsHistoryLen {
+		t.Errorf("len(History()) = %d, want %d", got, kpsHistoryLen)
+	}
+}
+
+func TestEngineRegistryReusesRunningEngineWithinGracePeriod(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	r := NewEngineRegistry(time.Minute)
+	engine, created := r.Acquire(database, player, &db.GameState{PlayerID: "p1", Realm: "main", ProductionRate: 10})
+	if !created {
+		t.Fatal("Acquire() created = false, want true for a player with no running Engine yet")
+	}
+	engine.tick(time.Second)
+	r.Release("p1")
+
+	again, created := r.Acquire(database, player, &db.GameState{PlayerID: "p1", Realm: "main"})
+	t.Cleanup(again.Stop)
+
+	if again != engine {
+		t.Fatal("Acquire() returned a different Engine within the grace period, want the same one")
+	}
+	if created {
+		t.Error("Acquire() created = true within the grace period, want false")
+	}
+	if state := again.State(); state.Keystrokes != 10 {
+		t.Errorf("Keystrokes = %v, want the 10 produced before reconnecting", state.Keystrokes)
+	}
+}
+
+func TestEngineRegistryStopsEngineAfterGracePeriod(t *testing.T) {
+	database := newTestDB(t)
+	player := &db.Player{ID: "p1", Username: "monkey"}
+	if err := database.SavePlayer(player); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	r := NewEngineRegistry(10 * time.Millisecond)
+	engine, _ := r.Acquire(database, player, &db.GameState{PlayerID: "p1", Realm: "main"})
+	r.Release("p1")
+
+	time.Sleep(50 * time.Millisecond)
+
+	select {
+	case <-engine.done:
+	case <-time.After(time.Second):
+		t.Fatal("Engine was not stopped after its grace period elapsed")
+	}
+}
@@ -0,0 +1,139 @@
+package ssh
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+// execLeaderboardLimit caps the number of rows a leaderboard exec command
+// prints, matching the API's default page size.
+const execLeaderboardLimit = 50
+
+// exportPayload is everything printed by the export exec command, enough
+// for a player to keep an offline copy of their progress.
+type exportPayload struct {
+	Player        *db.Player        `json:"player"`
+	GameState     *db.GameState     `json:"game_state"`
+	LifetimeStats *db.LifetimeStats `json:"lifetime_stats"`
+}
+
+// execCommandMiddleware serves ssh play@server leaderboard|stats|export as
+// non-interactive commands that print their result and exit, without
+// launching the TUI. status is the same, but restricted to the keys
+// listed in cfg.AdminFingerprints, and prompted for a TOTP code first if
+// cfg.AdminTOTP has one configured for that key. Any other command,
+// including a plain "ssh play@server" with no command at all, falls
+// through to next.
+func execCommandMiddleware(database db.Database, cfg Config, metrics *Metrics, engines *EngineRegistry) wish.Middleware {
+	return func(next cssh.Handler) cssh.Handler {
+		return func(sess cssh.Session) {
+			cmd := sess.Command()
+			if len(cmd) == 0 {
+				next(sess)
+				return
+			}
+
+			player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+			if player == nil {
+				wish.Fatalln(sess, "authentication did not complete")
+				return
+			}
+
+			var err error
+			switch cmd[0] {
+			case "leaderboard":
+				err = runLeaderboardCommand(sess, database, player)
+			case "stats":
+				err = runStatsCommand(sess, database, player)
+			case "export":
+				err = runExportCommand(sess, database, player)
+			case "status":
+				key := sess.PublicKey()
+				if key == nil {
+					wish.Fatalln(sess, "status is restricted to admins")
+					return
+				}
+				fp := fingerprint(key)
+				if !isAdminFingerprint(cfg.AdminFingerprints, fp) {
+					wish.Fatalln(sess, "status is restricted to admins")
+					return
+				}
+				if secret, ok := cfg.AdminTOTP[fp]; ok {
+					if err := verifyTOTPPrompt(sess, secret); err != nil {
+						wish.Fatalln(sess, err.Error())
+						return
+					}
+				}
+				err = runStatusCommand(sess, database, metrics, engines)
+			default:
+				wish.Fatalln(sess, fmt.Sprintf("unknown command %q", cmd[0]))
+				return
+			}
+			if err != nil {
+				wish.Fatalln(sess, err.Error())
+				return
+			}
+		}
+	}
+}
+
+// runLeaderboardCommand prints the top of the player's realm leaderboard,
+// ranked by keystrokes like the API's default.
+func runLeaderboardCommand(w io.Writer, database db.Database, player *db.Player) error {
+	entries, err := database.GetLeaderboard(player.Realm, db.SortByKeystrokes, 0, execLeaderboardLimit, 0)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		fmt.Fprintf(w, "%3d. %-16s %10s keystrokes  %6s words  %4s programs\n",
+			i+1, entry.Username,
+			ui.FormatNumber(entry.Keystrokes, false),
+			ui.FormatNumber(float64(entry.Words), false),
+			ui.FormatNumber(float64(entry.Programs), false))
+	}
+	return nil
+}
+
+// runStatsCommand prints the player's lifetime stats.
+func runStatsCommand(w io.Writer, database db.Database, player *db.Player) error {
+	stats, err := database.GetLifetimeStats(player.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "keystrokes: %s\n", ui.FormatNumber(stats.TotalKeystrokes, false))
+	fmt.Fprintf(w, "words: %s\n", ui.FormatNumber(float64(stats.TotalWords), false))
+	fmt.Fprintf(w, "programs: %s\n", ui.FormatNumber(float64(stats.TotalPrograms), false))
+	fmt.Fprintf(w, "playtime_seconds: %d\n", stats.PlaytimeSeconds)
+	fmt.Fprintf(w, "sessions_played: %d\n", stats.SessionsPlayed)
+	return nil
+}
+
+// runExportCommand prints the player's game state and lifetime stats as
+// JSON, so it can be redirected to a file for backup.
+func runExportCommand(w io.Writer, database db.Database, player *db.Player) error {
+	state, _, err := LoadOrNewGameState(database, player.ID, player.Realm)
+	if err != nil {
+		return err
+	}
+	stats, err := database.GetLifetimeStats(player.ID)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(exportPayload{
+		Player:        player,
+		GameState:     state,
+		LifetimeStats: stats,
+	})
+}
@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestRunLeaderboardCommandPrintsEntries(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := database.UpdateLeaderboard(&db.LeaderboardEntry{
+		PlayerID: player.ID, Realm: "main", Username: "monkey", Keystrokes: 100, Words: 20, Programs: 1,
+	}); err != nil {
+		t.Fatalf("UpdateLeaderboard() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runLeaderboardCommand(&buf, database, player); err != nil {
+		t.Fatalf("runLeaderboardCommand() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "monkey") {
+		t.Errorf("output = %q, want it to mention the leaderboard entry", buf.String())
+	}
+}
+
+func TestRunStatsCommandPrintsLifetimeStats(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.AddLifetimeStats("p1", db.LifetimeStats{TotalKeystrokes: 500, TotalWords: 10}); err != nil {
+		t.Fatalf("AddLifetimeStats() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runStatsCommand(&buf, database, &db.Player{ID: "p1"}); err != nil {
+		t.Fatalf("runStatsCommand() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "keystrokes: 500") {
+		t.Errorf("output = %q, want it to mention the keystroke total", buf.String())
+	}
+}
+
+func TestRunExportCommandPrintsValidJSON(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runExportCommand(&buf, database, player); err != nil {
+		t.Fatalf("runExportCommand() error = %v", err)
+	}
+
+	var payload exportPayload
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if payload.Player.Username != "monkey" {
+		t.Errorf("payload.Player.Username = %q, want %q", payload.Player.Username, "monkey")
+	}
+	if payload.GameState == nil {
+		t.Error("payload.GameState = nil, want the player's initial state")
+	}
+}
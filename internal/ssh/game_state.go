@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"errors"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+// MaxOfflineDuration caps how much elapsed time LoadOrNewGameState credits
+// toward a reconnecting player's offline earnings, so a save left
+// untouched for weeks doesn't grant weeks of production the instant its
+// player reconnects.
+const MaxOfflineDuration = 24 * time.Hour
+
+// LoadOrNewGameState loads the player's saved progress within realm, or
+// returns a fresh GameState if they've never played there before. Callers
+// should use this to initialize the game on connect, rather than always
+// starting from a blank state and silently discarding whatever was saved
+// last session. If the player had saved progress, whatever their
+// ProductionRate would have produced since then is credited to the
+// returned state and reported in the second return value, so the caller
+// can show them a summary instead of their numbers just changing.
+func LoadOrNewGameState(database db.Database, playerID, realm string) (*db.GameState, ui.OfflineEarnings, error) {
+	state, err := database.GetGameState(playerID, realm)
+	if errors.Is(err, db.ErrNotFound) {
+		return &db.GameState{PlayerID: playerID, Realm: realm, ProductionRate: game.BaseProductionRate}, ui.OfflineEarnings{}, nil
+	}
+	if err != nil {
+		return nil, ui.OfflineEarnings{}, err
+	}
+	earnings := creditOfflineEarnings(state)
+	return state, earnings, nil
+}
+
+// creditOfflineEarnings credits state with whatever its ProductionRate
+// would have produced since it was last saved, capped at
+// MaxOfflineDuration, and reports what it credited.
+func creditOfflineEarnings(state *db.GameState) ui.OfflineEarnings {
+	if state.UpdatedAt.IsZero() {
+		return ui.OfflineEarnings{}
+	}
+	away := time.Since(state.UpdatedAt)
+	if away <= 0 {
+		return ui.OfflineEarnings{}
+	}
+	if away > MaxOfflineDuration {
+		away = MaxOfflineDuration
+	}
+
+	keystrokes := state.ProductionRate * away.Seconds()
+	state.Keystrokes += keystrokes
+	return ui.OfflineEarnings{Away: away, Keystrokes: keystrokes}
+}
+
+// SaveState persists state for this session's player, so progress survives
+// disconnects rather than being lost when the connection ends.
+func (s *Session) SaveState(state *db.GameState) error {
+	state.PlayerID = s.PlayerID
+	return s.db.SaveGameState(state)
+}
@@ -0,0 +1,118 @@
+package ssh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+func TestLoadOrNewGameStateStartsFreshForFirstConnection(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	state, _, err := LoadOrNewGameState(database, "p1", "main")
+	if err != nil {
+		t.Fatalf("LoadOrNewGameState() error = %v", err)
+	}
+	if state.PlayerID != "p1" || state.CurrentLevel != 0 {
+		t.Errorf("LoadOrNewGameState() = %+v, want fresh state for p1", state)
+	}
+	if state.ProductionRate != game.BaseProductionRate {
+		t.Errorf("LoadOrNewGameState() ProductionRate = %v, want %v so a brand-new player can earn toward their first upgrade", state.ProductionRate, game.BaseProductionRate)
+	}
+}
+
+func TestLoadOrNewGameStateLoadsSavedProgress(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := database.SaveGameState(&db.GameState{PlayerID: "p1", Realm: "main", CurrentLevel: 5, Keystrokes: 1000}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	state, _, err := LoadOrNewGameState(database, "p1", "main")
+	if err != nil {
+		t.Fatalf("LoadOrNewGameState() error = %v", err)
+	}
+	if state.CurrentLevel != 5 || state.Keystrokes != 1000 {
+		t.Errorf("LoadOrNewGameState() = %+v, want the saved progress", state)
+	}
+}
+
+func TestLoadOrNewGameStateCreditsOfflineEarnings(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+	if err := database.SaveGameState(&db.GameState{PlayerID: "p1", Realm: "main", Keystrokes: 1000, ProductionRate: 5}); err != nil {
+		t.Fatalf("SaveGameState() error = %v", err)
+	}
+
+	state, earnings, err := LoadOrNewGameState(database, "p1", "main")
+	if err != nil {
+		t.Fatalf("LoadOrNewGameState() error = %v", err)
+	}
+	if earnings.Keystrokes <= 0 {
+		t.Errorf("earnings.Keystrokes = %v, want a positive credit for time since the last save", earnings.Keystrokes)
+	}
+	if state.Keystrokes != 1000+earnings.Keystrokes {
+		t.Errorf("state.Keystrokes = %v, want the saved progress plus the credited earnings", state.Keystrokes)
+	}
+}
+
+func TestLoadOrNewGameStateStartsFreshWithoutOfflineEarnings(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	_, earnings, err := LoadOrNewGameState(database, "p1", "main")
+	if err != nil {
+		t.Fatalf("LoadOrNewGameState() error = %v", err)
+	}
+	if earnings.Keystrokes != 0 {
+		t.Errorf("earnings.Keystrokes = %v, want 0 for a player with no saved progress to have been away from", earnings.Keystrokes)
+	}
+}
+
+func TestCreditOfflineEarningsCapsAtMaxOfflineDuration(t *testing.T) {
+	state := &db.GameState{ProductionRate: 5, UpdatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+
+	earnings := creditOfflineEarnings(state)
+
+	if earnings.Away != MaxOfflineDuration {
+		t.Errorf("earnings.Away = %v, want it capped at %v", earnings.Away, MaxOfflineDuration)
+	}
+	if want := state.ProductionRate * MaxOfflineDuration.Seconds(); earnings.Keystrokes != want {
+		t.Errorf("earnings.Keystrokes = %v, want %v", earnings.Keystrokes, want)
+	}
+}
+
+func TestSessionSaveStatePersistsProgress(t *testing.T) {
+	database := newTestDB(t)
+	if err := database.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	session, err := NewSession(database, "p1", "203.0.113.5")
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	if err := session.SaveState(&db.GameState{Realm: "main", CurrentLevel: 3}); err != nil {
+		t.Fatalf("SaveState() error = %v", err)
+	}
+
+	state, err := database.GetGameState("p1", "main")
+	if err != nil {
+		t.Fatalf("GetGameState() error = %v", err)
+	}
+	if state.CurrentLevel != 3 {
+		t.Errorf("CurrentLevel = %d, want 3", state.CurrentLevel)
+	}
+}
@@ -0,0 +1,57 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// hostKeyFileMode restricts the host key file to the owner, since anyone
+// who can read it can impersonate this server to every client that has
+// seen its fingerprint.
+const hostKeyFileMode = 0o600
+
+// LoadOrGenerateHostKey loads the server's persistent host key from path,
+// generating a new Ed25519 key and writing it there if it doesn't exist
+// yet. The returned signer is what the SSH server should present to
+// clients, so its fingerprint stays stable across restarts.
+func LoadOrGenerateHostKey(path string) (ssh.Signer, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		raw, err = generateHostKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load host key %s: %w", path, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// generateHostKey creates a new Ed25519 host key, persists it to path, and
+// returns its PEM encoding.
+func generateHostKey(path string) ([]byte, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, "term-idle host key")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal host key: %w", err)
+	}
+	raw := pem.EncodeToMemory(block)
+
+	if err := os.WriteFile(path, raw, hostKeyFileMode); err != nil {
+		return nil, fmt.Errorf("failed to write host key %s: %w", path, err)
+	}
+	return raw, nil
+}
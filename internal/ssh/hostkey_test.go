@@ -0,0 +1,36 @@
+package ssh
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadOrGenerateHostKeyGeneratesOnFirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host_key")
+
+	signer, err := LoadOrGenerateHostKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() error = %v", err)
+	}
+	if signer.PublicKey() == nil {
+		t.Fatal("LoadOrGenerateHostKey() returned a signer with no public key")
+	}
+}
+
+func TestLoadOrGenerateHostKeyPersistsFingerprintAcrossLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host_key")
+
+	first, err := LoadOrGenerateHostKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() error = %v", err)
+	}
+
+	second, err := LoadOrGenerateHostKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() on existing file error = %v", err)
+	}
+
+	if string(first.PublicKey().Marshal()) != string(second.PublicKey().Marshal()) {
+		t.Error("LoadOrGenerateHostKey() returned a different key on the second load")
+	}
+}
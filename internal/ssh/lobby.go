@@ -0,0 +1,192 @@
+package ssh
+
+import (
+	"errors"
+	"sync"
+)
+
+// lobbyInboxSize bounds how many unread messages a paired player's inbox
+// holds before new ones are dropped, so a slow reader can't make Send
+// block the sender indefinitely.
+const lobbyInboxSize = 16
+
+// ErrAlreadyPaired is returned by Lobby.Join when playerID is already
+// matched with someone.
+var ErrAlreadyPaired = errors.New("player is already paired")
+
+// ErrCancelled is returned by Lobby.Join when cancel closes before a
+// partner is found.
+var ErrCancelled = errors.New("matchmaking cancelled")
+
+// Pair is two players matched together by a Lobby, for a race or co-op
+// session. Either side can send the other a message through it once
+// matched; nothing about a Pair is specific to any one game mode.
+type Pair struct {
+	Players [2]string
+
+	mu      sync.Mutex
+	inboxes map[string]chan string
+	closed  bool
+}
+
+func newPair(a, b string) *Pair {
+	return &Pair{
+		Players: [2]string{a, b},
+		inboxes: map[string]chan string{
+			a: make(chan string, lobbyInboxSize),
+			b: make(chan string, lobbyInboxSize),
+		},
+	}
+}
+
+// Other returns the player on the other side of the pair from playerID.
+func (p *Pair) Other(playerID string) string {
+	if p.Players[0] == playerID {
+		return p.Players[1]
+	}
+	return p.Players[0]
+}
+
+// Send delivers msg to the other player in the pair from sender. If that
+// player's inbox is full, msg is dropped rather than blocking sender.
+func (p *Pair) Send(sender, msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+
+	select {
+	case p.inboxes[p.Other(sender)] <- msg:
+	default:
+	}
+}
+
+// Messages returns the channel playerID receives the other player's
+// messages on. It's closed once the pair ends, via either Close or a
+// Lobby.Leave call by either player.
+func (p *Pair) Messages(playerID string) <-chan string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inboxes[playerID]
+}
+
+// Close ends the pair, closing both players' message channels so any
+// blocked Messages receiver unblocks.
+func (p *Pair) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for _, ch := range p.inboxes {
+		close(ch)
+	}
+}
+
+// lobbyWaiter is one player parked in a Lobby's queue, waiting for a
+// partner.
+type lobbyWaiter struct {
+	playerID string
+	matched  chan *Pair
+}
+
+// Lobby matches players waiting for a race or co-op partner into Pairs,
+// first-come first-served. It's foundational plumbing for multiplayer
+// features: nothing in the game client queues a player here yet, but
+// sessions can be paired and exchange messages through it once one does.
+type Lobby struct {
+	mu      sync.Mutex
+	waiting []*lobbyWaiter
+	pairs   map[string]*Pair
+}
+
+// NewLobby builds an empty Lobby.
+func NewLobby() *Lobby {
+	return &Lobby{pairs: make(map[string]*Pair)}
+}
+
+// Join enqueues playerID for matchmaking, returning the Pair once another
+// waiting player is matched with them. If someone is already waiting, the
+// two are paired immediately and Join returns without blocking; otherwise
+// it blocks until a partner joins or cancel closes, whichever comes first.
+// It fails with ErrAlreadyPaired if playerID is already matched.
+func (l *Lobby) Join(playerID string, cancel <-chan struct{}) (*Pair, error) {
+	l.mu.Lock()
+	if _, ok := l.pairs[playerID]; ok {
+		l.mu.Unlock()
+		return nil, ErrAlreadyPaired
+	}
+
+	if len(l.waiting) > 0 {
+		partner := l.waiting[0]
+		l.waiting = l.waiting[1:]
+
+		pair := newPair(partner.playerID, playerID)
+		l.pairs[partner.playerID] = pair
+		l.pairs[playerID] = pair
+		l.mu.Unlock()
+
+		partner.matched <- pair
+		return pair, nil
+	}
+
+	w := &lobbyWaiter{playerID: playerID, matched: make(chan *Pair, 1)}
+	l.waiting = append(l.waiting, w)
+	l.mu.Unlock()
+
+	select {
+	case pair := <-w.matched:
+		return pair, nil
+	case <-cancel:
+		l.abandon(w)
+		return nil, ErrCancelled
+	}
+}
+
+// Pair returns the Pair playerID is currently matched into, if any.
+func (l *Lobby) Pair(playerID string) (*Pair, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pair, ok := l.pairs[playerID]
+	return pair, ok
+}
+
+// Leave removes playerID from the waiting queue or an active pair,
+// closing the pair if they were in one so their partner's Messages
+// channel unblocks. It's a no-op if playerID is neither queued nor
+// paired.
+func (l *Lobby) Leave(playerID string) {
+	l.mu.Lock()
+	pair, paired := l.pairs[playerID]
+	if paired {
+		delete(l.pairs, playerID)
+		delete(l.pairs, pair.Other(playerID))
+	}
+	for i, w := range l.waiting {
+		if w.playerID == playerID {
+			l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+			break
+		}
+	}
+	l.mu.Unlock()
+
+	if paired {
+		pair.Close()
+	}
+}
+
+// abandon removes w from the queue after its Join call was cancelled
+// while still waiting, so it doesn't take up a queue slot forever.
+func (l *Lobby) abandon(w *lobbyWaiter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, other := range l.waiting {
+		if other == w {
+			l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package ssh
+
+import (
+	"testing"
+	"time"
+)
+
+type joinResult struct {
+	pair *Pair
+	err  error
+}
+
+func TestLobbyJoinPairsTwoWaitingPlayers(t *testing.T) {
+	lobby := NewLobby()
+
+	done := make(chan joinResult, 1)
+	go func() {
+		pair, err := lobby.Join("monkey", nil)
+		done <- joinResult{pair, err}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	gorillaPair, err := lobby.Join("gorilla", nil)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	var monkeyResult joinResult
+	select {
+	case monkeyResult = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the waiting Join() call never returned once a partner joined")
+	}
+	if monkeyResult.err != nil {
+		t.Fatalf("Join() error = %v", monkeyResult.err)
+	}
+
+	if monkeyResult.pair != gorillaPair {
+		t.Error("Join() returned different Pairs for the two matched players")
+	}
+	if monkeyResult.pair.Other("monkey") != "gorilla" {
+		t.Errorf("Other(monkey) = %q, want gorilla", monkeyResult.pair.Other("monkey"))
+	}
+}
+
+func TestLobbyJoinRejectsAlreadyPairedPlayer(t *testing.T) {
+	lobby := NewLobby()
+
+	go lobby.Join("monkey", nil)
+	time.Sleep(20 * time.Millisecond)
+	if _, err := lobby.Join("gorilla", nil); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	if _, err := lobby.Join("monkey", nil); err != ErrAlreadyPaired {
+		t.Errorf("Join() error = %v, want %v", err, ErrAlreadyPaired)
+	}
+}
+
+func TestLobbyJoinCancelsWhileWaiting(t *testing.T) {
+	lobby := NewLobby()
+	cancel := make(chan struct{})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := lobby.Join("monkey", cancel)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(cancel)
+
+	select {
+	case err := <-done:
+		if err != ErrCancelled {
+			t.Errorf("Join() error = %v, want %v", err, ErrCancelled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Join() never returned after cancel closed")
+	}
+
+	if _, ok := lobby.Pair("monkey"); ok {
+		t.Error("Pair() found a pair for a cancelled waiter, want none")
+	}
+}
+
+func TestPairSendDeliversMessageToOtherPlayer(t *testing.T) {
+	lobby := NewLobby()
+
+	go lobby.Join("monkey", nil)
+	time.Sleep(20 * time.Millisecond)
+	pair, err := lobby.Join("gorilla", nil)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	pair.Send("gorilla", "ready?")
+
+	select {
+	case msg := <-pair.Messages("monkey"):
+		if msg != "ready?" {
+			t.Errorf("message = %q, want %q", msg, "ready?")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("monkey never received gorilla's message")
+	}
+}
+
+func TestLobbyLeaveClosesPairAndUnblocksPartner(t *testing.T) {
+	lobby := NewLobby()
+
+	go lobby.Join("monkey", nil)
+	time.Sleep(20 * time.Millisecond)
+	pair, err := lobby.Join("gorilla", nil)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	lobby.Leave("gorilla")
+
+	select {
+	case _, ok := <-pair.Messages("monkey"):
+		if ok {
+			t.Error("Messages() channel produced a value, want it closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("monkey's Messages channel never unblocked after gorilla left")
+	}
+
+	if _, ok := lobby.Pair("monkey"); ok {
+		t.Error("Pair() still found a pair for monkey after gorilla left")
+	}
+}
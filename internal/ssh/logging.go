@@ -0,0 +1,95 @@
+package ssh
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// logRotation controls how the SSH gateway's log file is rotated when
+// cfg.LogDir is set, keeping a bounded amount of history on disk.
+const (
+	logMaxSizeMB  = 100
+	logMaxBackups = 10
+	logMaxAgeDays = 28
+)
+
+// NewLogger builds the structured logger used for auth attempts, key
+// fingerprints, commands, and errors on the SSH gateway. If cfg.LogDir is
+// set, it writes JSON lines to a rotating file underneath it; otherwise it
+// logs to stdout, matching the rest of the application's default.
+func NewLogger(cfg Config) (*slog.Logger, error) {
+	if cfg.LogDir == "" {
+		return slog.New(slog.NewJSONHandler(os.Stdout, nil)), nil
+	}
+
+	if err := os.MkdirAll(cfg.LogDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	writer := &lumberjack.Logger{
+		Filename:   filepath.Join(cfg.LogDir, "ssh.log"),
+		MaxSize:    logMaxSizeMB,
+		MaxBackups: logMaxBackups,
+		MaxAge:     logMaxAgeDays,
+	}
+	return slog.New(slog.NewJSONHandler(writer, nil)), nil
+}
+
+// fingerprint returns the SHA256 fingerprint of key, in the same format
+// ConnectionFilter uses for key bans, so log entries can be cross-checked
+// against the ban list.
+func fingerprint(key ssh.PublicKey) string {
+	return ssh.FingerprintSHA256(key)
+}
+
+// playerID returns player.ID, or "" if authentication hadn't resolved a
+// player yet (e.g. a rejected connection never reaches a session handler).
+func playerID(player *db.Player) string {
+	if player == nil {
+		return ""
+	}
+	return player.ID
+}
+
+// sessionLoggingMiddleware logs the start and end of every session,
+// including any exec command run and the client's reported SSH version, so
+// operators have an audit trail to investigate abuse independently of the
+// metrics counters. golang.org/x/crypto/ssh doesn't expose which cipher,
+// MAC, or key exchange algorithm a session negotiated, so that can't be
+// logged here; the client version string is the closest signal available
+// for spotting outdated or unusual clients.
+func sessionLoggingMiddleware(logger *slog.Logger) wish.Middleware {
+	return func(next cssh.Handler) cssh.Handler {
+		return func(sess cssh.Session) {
+			start := time.Now()
+			player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+
+			logger.Info("session started",
+				"username", sess.User(),
+				"player_id", playerID(player),
+				"remote_addr", remoteIP(sess.RemoteAddr()),
+				"client_version", sess.Context().ClientVersion(),
+				"command", sess.Command(),
+			)
+
+			next(sess)
+
+			logger.Info("session ended",
+				"username", sess.User(),
+				"player_id", playerID(player),
+				"remote_addr", remoteIP(sess.RemoteAddr()),
+				"client_version", sess.Context().ClientVersion(),
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ssh
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cssh "github.com/charmbracelet/ssh"
+)
+
+func TestNewLoggerWritesToLogDir(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewLogger(Config{LogDir: dir})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+
+	logger.Info("test event", "key", "value")
+
+	raw, err := os.ReadFile(filepath.Join(dir, "ssh.log"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if len(raw) == 0 {
+		t.Error("log file is empty, want the logged event")
+	}
+}
+
+func TestNewLoggerWithoutLogDirSucceeds(t *testing.T) {
+	if _, err := NewLogger(Config{}); err != nil {
+		t.Fatalf("NewLogger() error = %v, want stdout fallback to succeed", err)
+	}
+}
+
+func TestSessionLoggingMiddlewareRecordsClientVersionAndDuration(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	ctx := &loggingTestContext{clientVersion: "SSH-2.0-OpenSSH_9.6"}
+	sess := &loggingTestSession{ctx: ctx}
+
+	handler := sessionLoggingMiddleware(logger)(func(cssh.Session) {})
+	handler(sess)
+
+	var started, ended bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if entry["client_version"] != "SSH-2.0-OpenSSH_9.6" {
+			t.Errorf("client_version = %v, want %q", entry["client_version"], "SSH-2.0-OpenSSH_9.6")
+		}
+		switch entry["msg"] {
+		case "session started":
+			started = true
+		case "session ended":
+			ended = true
+			if _, ok := entry["duration_ms"]; !ok {
+				t.Error("session ended entry is missing duration_ms")
+			}
+		}
+	}
+	if !started || !ended {
+		t.Errorf("started = %v, ended = %v, want both log lines", started, ended)
+	}
+}
+
+// loggingTestSession is a minimal cssh.Session stand-in exposing the
+// methods sessionLoggingMiddleware needs.
+type loggingTestSession struct {
+	cssh.Session
+	ctx *loggingTestContext
+}
+
+func (s *loggingTestSession) Context() cssh.Context { return s.ctx }
+func (s *loggingTestSession) User() string          { return "monkey" }
+func (s *loggingTestSession) Command() []string     { return nil }
+func (s *loggingTestSession) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("203.0.113.1"), Port: 22}
+}
+
+// loggingTestContext is a minimal cssh.Context stand-in exposing the
+// methods sessionLoggingMiddleware needs from a session's context.
+type loggingTestContext struct {
+	cssh.Context
+	clientVersion string
+}
+
+func (c *loggingTestContext) Value(key interface{}) interface{} { return nil }
+func (c *loggingTestContext) ClientVersion() string             { return c.clientVersion }
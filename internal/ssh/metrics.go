@@ -0,0 +1,105 @@
+package ssh
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics tracks operational counters for the SSH gateway: how many
+// sessions are open right now, how many players are actively connected,
+// and how often connections start, end, or fail authentication. It
+// implements expvar.Var, so a caller can publish it directly with
+// expvar.Publish for operators scraping /debug/vars.
+type Metrics struct {
+	startedAt time.Time
+
+	activeSessions   atomic.Int64
+	totalConnects    atomic.Int64
+	totalDisconnects atomic.Int64
+	authFailures     atomic.Int64
+
+	mu               sync.Mutex
+	sessionsByPlayer map[string]int64
+}
+
+// NewMetrics returns a Metrics with every counter at zero and its uptime
+// clock starting now.
+func NewMetrics() *Metrics {
+	return &Metrics{startedAt: time.Now(), sessionsByPlayer: make(map[string]int64)}
+}
+
+// Uptime returns how long this Metrics (and so the server it's tracking)
+// has been running.
+func (m *Metrics) Uptime() time.Duration {
+	return time.Since(m.startedAt)
+}
+
+// RecordConnect counts a newly established session for playerID.
+func (m *Metrics) RecordConnect(playerID string) {
+	m.activeSessions.Add(1)
+	m.totalConnects.Add(1)
+
+	m.mu.Lock()
+	m.sessionsByPlayer[playerID]++
+	m.mu.Unlock()
+}
+
+// RecordDisconnect counts the end of a session for playerID.
+func (m *Metrics) RecordDisconnect(playerID string) {
+	m.activeSessions.Add(-1)
+	m.totalDisconnects.Add(1)
+
+	m.mu.Lock()
+	if m.sessionsByPlayer[playerID] <= 1 {
+		delete(m.sessionsByPlayer, playerID)
+	} else {
+		m.sessionsByPlayer[playerID]--
+	}
+	m.mu.Unlock()
+}
+
+// RecordAuthFailure counts a connection rejected during authentication.
+func (m *Metrics) RecordAuthFailure() {
+	m.authFailures.Add(1)
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics' counters, safe to
+// marshal or inspect without racing further updates.
+type MetricsSnapshot struct {
+	UptimeSeconds    int64            `json:"uptime_seconds"`
+	ActiveSessions   int64            `json:"active_sessions"`
+	TotalConnects    int64            `json:"connects_total"`
+	TotalDisconnects int64            `json:"disconnects_total"`
+	AuthFailures     int64            `json:"auth_failures_total"`
+	SessionsByPlayer map[string]int64 `json:"sessions_by_player"`
+}
+
+// Snapshot returns the current value of every counter.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	byPlayer := make(map[string]int64, len(m.sessionsByPlayer))
+	for id, n := range m.sessionsByPlayer {
+		byPlayer[id] = n
+	}
+	m.mu.Unlock()
+
+	return MetricsSnapshot{
+		UptimeSeconds:    int64(m.Uptime().Seconds()),
+		ActiveSessions:   m.activeSessions.Load(),
+		TotalConnects:    m.totalConnects.Load(),
+		TotalDisconnects: m.totalDisconnects.Load(),
+		AuthFailures:     m.authFailures.Load(),
+		SessionsByPlayer: byPlayer,
+	}
+}
+
+// String implements expvar.Var.
+func (m *Metrics) String() string {
+	raw, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(raw)
+}
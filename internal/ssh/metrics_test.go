@@ -0,0 +1,57 @@
+package ssh
+
+import "testing"
+
+func TestMetricsTracksActiveSessions(t *testing.T) {
+	m := NewMetrics()
+
+	m.RecordConnect("p1")
+	m.RecordConnect("p2")
+	if snap := m.Snapshot(); snap.ActiveSessions != 2 {
+		t.Fatalf("ActiveSessions = %d, want 2", snap.ActiveSessions)
+	}
+
+	m.RecordDisconnect("p1")
+	snap := m.Snapshot()
+	if snap.ActiveSessions != 1 {
+		t.Errorf("ActiveSessions = %d, want 1", snap.ActiveSessions)
+	}
+	if snap.TotalConnects != 2 || snap.TotalDisconnects != 1 {
+		t.Errorf("TotalConnects/TotalDisconnects = %d/%d, want 2/1", snap.TotalConnects, snap.TotalDisconnects)
+	}
+}
+
+func TestMetricsTracksSessionsByPlayerAndCleansUpAtZero(t *testing.T) {
+	m := NewMetrics()
+
+	m.RecordConnect("p1")
+	m.RecordConnect("p1")
+	if snap := m.Snapshot(); snap.SessionsByPlayer["p1"] != 2 {
+		t.Fatalf("SessionsByPlayer[p1] = %d, want 2", snap.SessionsByPlayer["p1"])
+	}
+
+	m.RecordDisconnect("p1")
+	m.RecordDisconnect("p1")
+	if snap := m.Snapshot(); len(snap.SessionsByPlayer) != 0 {
+		t.Errorf("SessionsByPlayer = %+v, want empty once a player's last session ends", snap.SessionsByPlayer)
+	}
+}
+
+func TestMetricsRecordAuthFailure(t *testing.T) {
+	m := NewMetrics()
+
+	m.RecordAuthFailure()
+	m.RecordAuthFailure()
+	if snap := m.Snapshot(); snap.AuthFailures != 2 {
+		t.Errorf("AuthFailures = %d, want 2", snap.AuthFailures)
+	}
+}
+
+func TestMetricsStringIsValidJSON(t *testing.T) {
+	m := NewMetrics()
+	m.RecordConnect("p1")
+
+	if s := m.String(); s == "" || s == "{}" {
+		t.Errorf("String() = %q, want a non-empty JSON snapshot", s)
+	}
+}
@@ -0,0 +1,83 @@
+package ssh
+
+import (
+	"fmt"
+	"log/slog"
+
+	cssh "github.com/charmbracelet/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// pairingPromptAttempts bounds how many times a keyboard-interactive
+// client is asked to check back before the connection is rejected, so a
+// client that never claims its code doesn't hold the auth handshake open
+// forever.
+const pairingPromptAttempts = 3
+
+// keyboardInteractiveAuthHandler lets a client with no SSH key configured
+// (e.g. a mobile SSH app without a key agent) authenticate by claiming a
+// one-time code through the API instead of presenting a key. It issues a
+// code, prompts the client to claim it from a device they're already
+// logged in on, and checks for the claim each time the client presses
+// enter, accepting the connection as the claiming player once it sees
+// one. Every attempt is logged the same way publicKeyAuthHandler logs
+// public key attempts, so the audit trail covers both paths.
+func keyboardInteractiveAuthHandler(database db.Database, logger *slog.Logger) cssh.KeyboardInteractiveHandler {
+	return func(ctx cssh.Context, challenge gossh.KeyboardInteractiveChallenge) bool {
+		ip := remoteIP(ctx.RemoteAddr())
+
+		reject := func(reason string) bool {
+			logger.Info("auth attempt",
+				"username", ctx.User(), "remote_addr", ip, "method", "keyboard-interactive",
+				"success", false, "reason", reason,
+			)
+			if err := database.RecordAuthAttempt(ctx.User(), "", ip, false, reason); err != nil {
+				logger.Error("failed to record auth attempt", "error", err)
+			}
+			return false
+		}
+
+		code, err := database.IssuePairingCode()
+		if err != nil {
+			return reject(err.Error())
+		}
+
+		instruction := fmt.Sprintf(
+			"No SSH key offered. Log in to the Term Idle app or website on another device and submit this pairing code: %s\nPress enter once claimed (leave blank to cancel): ",
+			code,
+		)
+
+		for attempt := 0; attempt < pairingPromptAttempts; attempt++ {
+			answers, err := challenge("", instruction, []string{""}, []bool{true})
+			if err != nil || len(answers) == 0 || answers[0] == "" {
+				return reject("cancelled")
+			}
+
+			player, err := database.ResolvePairingCode(code)
+			if err == nil {
+				if player.Banned {
+					return reject("player banned")
+				}
+				if player.Suspended {
+					return reject("player suspended")
+				}
+
+				ctx.SetValue(contextKeyPlayer, player)
+				logger.Info("auth attempt",
+					"username", ctx.User(), "remote_addr", ip, "method", "keyboard-interactive",
+					"success", true, "player_id", player.ID,
+				)
+				if err := database.RecordAuthAttempt(ctx.User(), "", ip, true, ""); err != nil {
+					logger.Error("failed to record auth attempt", "error", err)
+				}
+				return true
+			}
+
+			instruction = fmt.Sprintf("Pairing code %s not claimed yet. Press enter to check again (leave blank to cancel): ", code)
+		}
+
+		return reject("pairing code not claimed in time")
+	}
+}
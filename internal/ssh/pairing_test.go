@@ -0,0 +1,114 @@
+package ssh
+
+import (
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	cssh "github.com/charmbracelet/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func newTestLogger(t *testing.T) *slog.Logger {
+	t.Helper()
+
+	logger, err := NewLogger(Config{})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	return logger
+}
+
+// extractPairingCode pulls the code keyboardInteractiveAuthHandler embeds
+// in its prompt instruction, so a fake challenge can claim it.
+func extractPairingCode(instruction string) string {
+	const marker = "pairing code: "
+	i := strings.Index(instruction, marker)
+	if i < 0 {
+		return ""
+	}
+	rest := instruction[i+len(marker):]
+	return strings.SplitN(rest, "\n", 2)[0]
+}
+
+func TestKeyboardInteractiveAuthHandlerAcceptsClaimedCode(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	ctx := &pairingTestContext{values: map[interface{}]interface{}{}}
+	handler := keyboardInteractiveAuthHandler(database, newTestLogger(t))
+
+	var code string
+	claimedOnce := false
+	challenge := func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+		code = extractPairingCode(instruction)
+		if !claimedOnce {
+			if err := database.ClaimPairingCode(code, player.ID); err != nil {
+				t.Fatalf("ClaimPairingCode() error = %v", err)
+			}
+			claimedOnce = true
+		}
+		return []string{"\n"}, nil
+	}
+
+	if !handler(ctx, challenge) {
+		t.Fatal("handler() = false, want true once the pairing code is claimed")
+	}
+	if got, _ := ctx.Value(contextKeyPlayer).(*db.Player); got == nil || got.ID != player.ID {
+		t.Errorf("contextKeyPlayer = %v, want player %s", got, player.ID)
+	}
+}
+
+func TestKeyboardInteractiveAuthHandlerRejectsCancelledPrompt(t *testing.T) {
+	database := newTestDB(t)
+
+	ctx := &pairingTestContext{values: map[interface{}]interface{}{}}
+	handler := keyboardInteractiveAuthHandler(database, newTestLogger(t))
+
+	challenge := func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+		return []string{""}, nil
+	}
+
+	if handler(ctx, challenge) {
+		t.Fatal("handler() = true, want false when the client leaves the prompt blank")
+	}
+}
+
+func TestKeyboardInteractiveAuthHandlerRejectsUnclaimedCodeAfterAttempts(t *testing.T) {
+	database := newTestDB(t)
+
+	ctx := &pairingTestContext{values: map[interface{}]interface{}{}}
+	handler := keyboardInteractiveAuthHandler(database, newTestLogger(t))
+
+	attempts := 0
+	challenge := func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+		attempts++
+		return []string{"\n"}, nil
+	}
+
+	if handler(ctx, challenge) {
+		t.Fatal("handler() = true, want false when the pairing code is never claimed")
+	}
+	if attempts != pairingPromptAttempts {
+		t.Errorf("attempts = %d, want %d", attempts, pairingPromptAttempts)
+	}
+}
+
+// pairingTestContext is a minimal cssh.Context stand-in exposing the
+// methods keyboardInteractiveAuthHandler needs.
+type pairingTestContext struct {
+	cssh.Context
+	values map[interface{}]interface{}
+}
+
+func (c *pairingTestContext) User() string { return "monkey" }
+func (c *pairingTestContext) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("203.0.113.1")}
+}
+func (c *pairingTestContext) Value(key interface{}) interface{} { return c.values[key] }
+func (c *pairingTestContext) SetValue(key, value interface{})   { c.values[key] = value }
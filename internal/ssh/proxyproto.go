@@ -0,0 +1,50 @@
+package ssh
+
+import (
+	"net"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/pires/go-proxyproto"
+)
+
+// ListenAndServe listens on srv.Addr and serves it, wrapping the listener
+// in PROXY protocol parsing first if cfg.ProxyProtocol is enabled. Use this
+// in place of the embedded cssh.Server's own ListenAndServe so a deployment
+// behind HAProxy or a network load balancer sees real client IPs for
+// logging, rate limiting, and bans rather than the balancer's own address.
+func ListenAndServe(srv *cssh.Server, cfg Config) error {
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":22"
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	ln, err = wrapProxyProtocol(ln, cfg.ProxyProtocol)
+	if err != nil {
+		return err
+	}
+
+	return srv.Serve(ln)
+}
+
+// wrapProxyProtocol wraps l so RemoteAddr on accepted connections reflects
+// the real client address from a PROXY v1/v2 header rather than the
+// upstream load balancer's, when cfg.Enabled. Connections from outside
+// cfg.TrustedCIDRs are dropped instead of trusted with whatever address
+// they claim to be.
+func wrapProxyProtocol(l net.Listener, cfg ProxyProtocolConfig) (net.Listener, error) {
+	if !cfg.Enabled {
+		return l, nil
+	}
+
+	connPolicy, err := proxyproto.TrustProxyHeaderFromRanges(cfg.TrustedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proxyproto.Listener{Listener: l, ConnPolicy: connPolicy}, nil
+}
@@ -0,0 +1,53 @@
+package ssh
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pires/go-proxyproto"
+)
+
+func TestWrapProxyProtocolDisabledReturnsListenerUnchanged(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	defer ln.Close()
+
+	wrapped, err := wrapProxyProtocol(ln, ProxyProtocolConfig{})
+	if err != nil {
+		t.Fatalf("wrapProxyProtocol() error = %v", err)
+	}
+	if wrapped != ln {
+		t.Error("wrapProxyProtocol() returned a different listener when disabled")
+	}
+}
+
+func TestWrapProxyProtocolEnabledWrapsListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	defer ln.Close()
+
+	wrapped, err := wrapProxyProtocol(ln, ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"10.0.0.0/8"}})
+	if err != nil {
+		t.Fatalf("wrapProxyProtocol() error = %v", err)
+	}
+	if _, ok := wrapped.(*proxyproto.Listener); !ok {
+		t.Errorf("wrapProxyProtocol() returned %T, want *proxyproto.Listener", wrapped)
+	}
+}
+
+func TestWrapProxyProtocolRejectsInvalidTrustedCIDR(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	defer ln.Close()
+
+	_, err = wrapProxyProtocol(ln, ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"not-an-address"}})
+	if err == nil {
+		t.Fatal("wrapProxyProtocol() error = nil, want an error for an invalid CIDR")
+	}
+}
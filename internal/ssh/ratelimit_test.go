@@ -0,0 +1,48 @@
+package ssh
+
+import "testing"
+
+func TestConnectionRateLimiterAllowsUpToBurstPerIP(t *testing.T) {
+	l := NewConnectionRateLimiter(RateLimitConfig{
+		PerIPConnectionsPerSecond: 1, PerIPBurst: 2,
+		GlobalConnectionsPerSecond: 1000, GlobalBurst: 1000,
+	})
+
+	if !l.Allow("203.0.113.1") {
+		t.Error("first connection should be allowed")
+	}
+	if !l.Allow("203.0.113.1") {
+		t.Error("second connection within burst should be allowed")
+	}
+	if l.Allow("203.0.113.1") {
+		t.Error("third connection beyond burst should be rejected")
+	}
+}
+
+func TestConnectionRateLimiterTracksIPsIndependently(t *testing.T) {
+	l := NewConnectionRateLimiter(RateLimitConfig{
+		PerIPConnectionsPerSecond: 1, PerIPBurst: 1,
+		GlobalConnectionsPerSecond: 1000, GlobalBurst: 1000,
+	})
+
+	if !l.Allow("203.0.113.1") {
+		t.Error("first IP's connection should be allowed")
+	}
+	if !l.Allow("203.0.113.2") {
+		t.Error("a different IP should have its own budget")
+	}
+}
+
+func TestConnectionRateLimiterEnforcesGlobalCap(t *testing.T) {
+	l := NewConnectionRateLimiter(RateLimitConfig{
+		PerIPConnectionsPerSecond: 1000, PerIPBurst: 1000,
+		GlobalConnectionsPerSecond: 1, GlobalBurst: 1,
+	})
+
+	if !l.Allow("203.0.113.1") {
+		t.Error("first connection should be allowed")
+	}
+	if l.Allow("203.0.113.2") {
+		t.Error("a second connection from a different IP should still hit the global cap")
+	}
+}
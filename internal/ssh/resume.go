@@ -0,0 +1,107 @@
+package ssh
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+// resumeGracePeriod is how long a disconnected player's in-memory game
+// session is kept around before it is discarded and a reconnect has to
+// fall back to loading progress from the database.
+const resumeGracePeriod = 2 * time.Minute
+
+// SessionRegistry retains a player's most recent ui.Model in memory for a
+// grace period after their connection drops, so reconnecting within that
+// window resumes exactly where they left off rather than starting a new
+// TUI session from whatever was last saved to the database.
+type SessionRegistry struct {
+	grace time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*retainedSession
+}
+
+// retainedSession is one player's held-open session. timer is nil while
+// the player is connected; Detach sets it, starting the countdown to
+// eviction, and Resume stops it again if they reconnect in time.
+type retainedSession struct {
+	model ui.Model
+	timer *time.Timer
+}
+
+// NewSessionRegistry returns a SessionRegistry that discards a
+// disconnected player's session after grace.
+func NewSessionRegistry(grace time.Duration) *SessionRegistry {
+	return &SessionRegistry{grace: grace, entries: make(map[string]*retainedSession)}
+}
+
+// Update replaces the retained model for playerID, called on every message
+// their session processes so whatever is retained is always current.
+func (r *SessionRegistry) Update(playerID string, model ui.Model) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[playerID]; ok {
+		entry.model = model
+		return
+	}
+	r.entries[playerID] = &retainedSession{model: model}
+}
+
+// Detach starts playerID's grace period, called once their connection
+// ends. If they don't reconnect before it elapses, the session is
+// discarded.
+func (r *SessionRegistry) Detach(playerID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[playerID]
+	if !ok {
+		return
+	}
+	entry.timer = time.AfterFunc(r.grace, func() { r.evict(playerID) })
+}
+
+// Resume returns playerID's retained model, if they reconnect before its
+// grace period elapses, removing it from the registry either way.
+func (r *SessionRegistry) Resume(playerID string) (ui.Model, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[playerID]
+	if !ok {
+		return ui.Model{}, false
+	}
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	delete(r.entries, playerID)
+	return entry.model, true
+}
+
+func (r *SessionRegistry) evict(playerID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, playerID)
+}
+
+// resumableModel wraps ui.Model to report every update back to a
+// SessionRegistry, so the player's latest state is always what gets
+// retained if their connection drops.
+type resumableModel struct {
+	ui.Model
+	playerID string
+	registry *SessionRegistry
+}
+
+// Update implements tea.Model.
+func (m resumableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.Model.Update(msg)
+	m.Model = next.(ui.Model)
+	m.registry.Update(m.playerID, m.Model)
+	return m, cmd
+}
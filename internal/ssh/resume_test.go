@@ -0,0 +1,71 @@
+package ssh
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+func TestSessionRegistryResumeWithinGracePeriod(t *testing.T) {
+	r := NewSessionRegistry(time.Minute)
+	model := ui.NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ui.ThemeDefault, ui.DefaultKeyMap(), ui.NewCatalog(ui.LocaleEN), nil, nil, ui.OfflineEarnings{}, nil, nil, nil)
+
+	r.Update("p1", model)
+	r.Detach("p1")
+
+	if _, ok := r.Resume("p1"); !ok {
+		t.Fatal("Resume() ok = false, want true within the grace period")
+	}
+	if _, ok := r.Resume("p1"); ok {
+		t.Error("Resume() ok = true on second call, want the session to be consumed by the first")
+	}
+}
+
+func TestSessionRegistryEvictsAfterGracePeriod(t *testing.T) {
+	r := NewSessionRegistry(10 * time.Millisecond)
+	model := ui.NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ui.ThemeDefault, ui.DefaultKeyMap(), ui.NewCatalog(ui.LocaleEN), nil, nil, ui.OfflineEarnings{}, nil, nil, nil)
+
+	r.Update("p1", model)
+	r.Detach("p1")
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, ok := r.Resume("p1"); ok {
+		t.Fatal("Resume() ok = true, want the session to have expired")
+	}
+}
+
+func TestSessionRegistryResumeWithoutSessionFails(t *testing.T) {
+	r := NewSessionRegistry(time.Minute)
+
+	if _, ok := r.Resume("nobody"); ok {
+		t.Fatal("Resume() ok = true, want false for a player with no retained session")
+	}
+}
+
+func TestResumableModelUpdateReportsToRegistry(t *testing.T) {
+	r := NewSessionRegistry(time.Minute)
+	m := resumableModel{
+		Model:    ui.NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ui.ThemeDefault, ui.DefaultKeyMap(), ui.NewCatalog(ui.LocaleEN), nil, nil, ui.OfflineEarnings{}, nil, nil, nil),
+		playerID: "p1",
+		registry: r,
+	}
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if _, ok := updated.(resumableModel); !ok {
+		t.Fatalf("Update() returned %T, want resumableModel", updated)
+	}
+
+	r.Detach("p1")
+	resumed, ok := r.Resume("p1")
+	if !ok {
+		t.Fatal("Resume() ok = false, want the update to have been retained")
+	}
+	if w, h := resumed.Size(); w != 80 || h != 24 {
+		t.Errorf("resumed model size = %d/%d, want 80/24", w, h)
+	}
+}
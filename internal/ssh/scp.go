@@ -0,0 +1,88 @@
+package ssh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/scp"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// saveFileName is the only path a player may download over scp, a JSON
+// snapshot of their progress built on demand from the database.
+const saveFileName = "save.json"
+
+// saveDownloadHandler exposes a read-only virtual save.json for the
+// authenticated player, letting them pull a backup of their progress with
+// `scp play@server:save.json ./save.json`. It implements
+// scp.CopyToClientHandler only; uploads are refused since there is nothing
+// to write.
+type saveDownloadHandler struct {
+	db db.Database
+}
+
+// scpMiddleware wraps next with wish's scp middleware, serving save.json
+// from saveDownloadHandler and falling through to next for every other
+// command, including a plain SSH session with no scp involved.
+func scpMiddleware(database db.Database) wish.Middleware {
+	return scp.Middleware(saveDownloadHandler{db: database}, nil)
+}
+
+// Glob only ever matches saveFileName; term-idle has no other files to
+// offer over scp.
+func (h saveDownloadHandler) Glob(sess cssh.Session, pattern string) ([]string, error) {
+	if pattern != saveFileName {
+		return nil, fmt.Errorf("no such file: %q", pattern)
+	}
+	return []string{saveFileName}, nil
+}
+
+// WalkDir is unused since save.json is never fetched recursively, but is
+// required by scp.CopyToClientHandler.
+func (h saveDownloadHandler) WalkDir(sess cssh.Session, path string, fn fs.WalkDirFunc) error {
+	return fmt.Errorf("recursive copy is not supported")
+}
+
+// NewDirEntry is unused for the same reason as WalkDir.
+func (h saveDownloadHandler) NewDirEntry(sess cssh.Session, path string) (*scp.DirEntry, error) {
+	return nil, fmt.Errorf("recursive copy is not supported")
+}
+
+// NewFileEntry builds save.json for the session's authenticated player.
+func (h saveDownloadHandler) NewFileEntry(sess cssh.Session, path string) (*scp.FileEntry, func() error, error) {
+	player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+	if player == nil {
+		return nil, nil, fmt.Errorf("authentication did not complete")
+	}
+
+	state, _, err := LoadOrNewGameState(h.db, player.ID, player.Realm)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats, err := h.db.GetLifetimeStats(player.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := json.MarshalIndent(exportPayload{
+		Player:        player,
+		GameState:     state,
+		LifetimeStats: stats,
+	}, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &scp.FileEntry{
+		Name:     saveFileName,
+		Filepath: saveFileName,
+		Mode:     0o600,
+		Size:     int64(len(raw)),
+		Reader:   bytes.NewReader(raw),
+	}, nil, nil
+}
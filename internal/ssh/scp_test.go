@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	cssh "github.com/charmbracelet/ssh"
+)
+
+func TestSaveDownloadHandlerGlobRejectsUnknownPath(t *testing.T) {
+	h := saveDownloadHandler{db: newTestDB(t)}
+
+	if _, err := h.Glob(nil, "../etc/passwd"); err == nil {
+		t.Fatal("Glob() error = nil, want an error for a path other than save.json")
+	}
+}
+
+func TestSaveDownloadHandlerNewFileEntryBuildsSave(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	ctx := &testSSHContext{values: map[interface{}]interface{}{contextKeyPlayer: player}}
+	h := saveDownloadHandler{db: database}
+
+	entry, closer, err := h.NewFileEntry(&testSSHSession{ctx: ctx}, saveFileName)
+	if err != nil {
+		t.Fatalf("NewFileEntry() error = %v", err)
+	}
+	if closer != nil {
+		t.Error("NewFileEntry() closer should be nil, nothing to close")
+	}
+	if entry.Name != saveFileName {
+		t.Errorf("entry.Name = %q, want %q", entry.Name, saveFileName)
+	}
+
+	raw, err := io.ReadAll(entry.Reader)
+	if err != nil {
+		t.Fatalf("io.ReadAll() error = %v", err)
+	}
+	var payload exportPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if payload.Player.Username != "monkey" {
+		t.Errorf("payload.Player.Username = %q, want %q", payload.Player.Username, "monkey")
+	}
+}
+
+// testSSHContext is a minimal cssh.Context stand-in for tests that only
+// need Value/SetValue.
+type testSSHContext struct {
+	cssh.Context
+	values map[interface{}]interface{}
+}
+
+func (c *testSSHContext) Value(key interface{}) interface{} { return c.values[key] }
+func (c *testSSHContext) SetValue(key, value interface{})   { c.values[key] = value }
+
+// testSSHSession is a minimal cssh.Session stand-in exposing only the
+// Context method that saveDownloadHandler.NewFileEntry needs.
+type testSSHSession struct {
+	cssh.Session
+	ctx cssh.Context
+}
+
+func (s *testSSHSession) Context() cssh.Context { return s.ctx }
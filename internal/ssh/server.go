@@ -0,0 +1,230 @@
+package ssh
+
+import (
+	"log/slog"
+	"net"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	bm "github.com/charmbracelet/wish/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/ui"
+)
+
+// notificationHistoryLimit bounds how many of a player's notifications the
+// game view's notification panel loads at once.
+const notificationHistoryLimit = 200
+
+// contextKeyPlayer stores the *db.Player resolved during public key
+// authentication, so the bubbletea handler doesn't need to authenticate a
+// second time.
+type contextKey string
+
+const (
+	contextKeyPlayer       contextKey = "player"
+	contextKeyResumedModel contextKey = "resumed_model"
+	contextKeyTUIStarted   contextKey = "tui_started"
+)
+
+// NewServer builds the SSH gateway players connect to. Authentication,
+// connection filtering, and rate limiting all happen before a client's
+// public key session is accepted; a game session only starts once all
+// three have passed. authenticator resolves the player for each
+// connection; pass nil to use the default KeyAuthenticator.
+func NewServer(database db.Database, authenticator Authenticator, cfg Config, addr string) (*cssh.Server, error) {
+	if authenticator == nil {
+		authenticator = KeyAuthenticator{Database: database, Realm: "main"}
+	}
+
+	if _, err := LoadOrGenerateHostKey(cfg.HostKeyFile); err != nil {
+		return nil, err
+	}
+
+	filter, err := NewConnectionFilter(database, cfg)
+	if err != nil {
+		return nil, err
+	}
+	logger, err := NewLogger(cfg)
+	if err != nil {
+		return nil, err
+	}
+	limiter := NewConnectionRateLimiter(cfg.RateLimit)
+	metrics := NewMetrics()
+	registry := NewSessionRegistry(resumeGracePeriod)
+	engines := NewEngineRegistry(resumeGracePeriod)
+	queue := NewCapacityQueue(cfg.Capacity)
+
+	return wish.NewServer(
+		wish.WithAddress(addr),
+		wish.WithHostKeyPath(cfg.HostKeyFile),
+		wish.WithPublicKeyAuth(publicKeyAuthHandler(database, authenticator, filter, limiter, metrics, logger)),
+		wish.WithKeyboardInteractiveAuth(keyboardInteractiveAuthHandler(database, logger)),
+		wish.WithMiddleware(
+			bm.Middleware(bubbleTeaHandler(database, cfg, metrics, registry, engines)),
+			activityMiddleware(database, logger),
+			execCommandMiddleware(database, cfg, metrics, engines),
+			scpMiddleware(database),
+			sessionResumeMiddleware(registry, engines, metrics),
+			capacityMiddleware(queue),
+			sessionLoggingMiddleware(logger),
+		),
+	)
+}
+
+// publicKeyAuthHandler rejects banned or rate-limited connections outright,
+// and otherwise resolves the presented key to a player via authenticator.
+// Every attempt is logged with its outcome, username, key fingerprint, and
+// source IP for operators investigating brute-force attempts or
+// impersonation.
+func publicKeyAuthHandler(database db.Database, authenticator Authenticator, filter *ConnectionFilter, limiter *ConnectionRateLimiter, metrics *Metrics, logger *slog.Logger) cssh.PublicKeyHandler {
+	return func(ctx cssh.Context, key cssh.PublicKey) bool {
+		ip := remoteIP(ctx.RemoteAddr())
+		fp := fingerprint(key)
+
+		reject := func(reason string) bool {
+			metrics.RecordAuthFailure()
+			logger.Info("auth attempt",
+				"username", ctx.User(), "fingerprint", fp, "remote_addr", ip,
+				"success", false, "reason", reason,
+			)
+			if err := database.RecordAuthAttempt(ctx.User(), fp, ip, false, reason); err != nil {
+				logger.Error("failed to record auth attempt", "error", err)
+			}
+			return false
+		}
+
+		if !limiter.Allow(ip) {
+			return reject("rate limited")
+		}
+
+		if banned, reason, err := filter.Check(ip, key); err != nil {
+			return reject(err.Error())
+		} else if banned {
+			return reject("banned: " + reason)
+		}
+
+		player, err := authenticator.Authenticate(ctx.User(), key)
+		if err != nil {
+			return reject(err.Error())
+		}
+		if player.Banned {
+			return reject("player banned")
+		}
+		if player.Suspended {
+			return reject("player suspended")
+		}
+
+		ctx.SetValue(contextKeyPlayer, player)
+		logger.Info("auth attempt",
+			"username", ctx.User(), "fingerprint", fp, "remote_addr", ip,
+			"success", true, "player_id", player.ID,
+		)
+		if err := database.RecordAuthAttempt(ctx.User(), fp, ip, true, ""); err != nil {
+			logger.Error("failed to record auth attempt", "error", err)
+		}
+		return true
+	}
+}
+
+// bubbleTeaHandler builds the game's UI for an authenticated session, using
+// the player Authenticate stashed on the context. If registry is holding a
+// session from a recent disconnect, that is resumed in place of loading
+// progress from the database. Either way, production for this player is
+// driven by their Engine in engines, not by this handler or the bubbletea
+// render loop, so progress made while they were disconnected is already
+// reflected in what gets rendered.
+func bubbleTeaHandler(database db.Database, cfg Config, metrics *Metrics, registry *SessionRegistry, engines *EngineRegistry) bm.Handler {
+	return func(sess cssh.Session) (tea.Model, []tea.ProgramOption) {
+		player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+		if player == nil {
+			wish.Fatalln(sess, "authentication did not complete")
+			return nil, nil
+		}
+
+		state, earnings, err := LoadOrNewGameState(database, player.ID, player.Realm)
+		if err != nil {
+			wish.Fatalln(sess, "failed to load your saved progress")
+			return nil, nil
+		}
+		engine, created := engines.Acquire(database, player, state)
+		if !created {
+			earnings = ui.OfflineEarnings{}
+		}
+
+		model, ok := sess.Context().Value(contextKeyResumedModel).(ui.Model)
+		if !ok {
+			banner, err := cfg.Banner()
+			if err != nil {
+				banner = ""
+			}
+			snapshot := engine.State()
+			notifications := func() []*db.Notification {
+				notifications, err := database.GetNotificationsSince(player.ID, time.Time{}, notificationHistoryLimit)
+				if err != nil {
+					return nil
+				}
+				return notifications
+			}
+			leaderboard := func(offset, limit int) []*db.LeaderboardEntry {
+				entries, err := database.GetLeaderboard(player.Realm, db.SortByKeystrokes, 0, limit, offset)
+				if err != nil {
+					return nil
+				}
+				return entries
+			}
+			model = ui.NewModelWithAll(player, &snapshot, banner, cfg.Theme, ui.LoadKeyMap(cfg.KeyBindings), ui.NewCatalog(cfg.Locale), engine.History, notifications, earnings, leaderboard, engine.Save, engine.Purchase)
+		}
+
+		sess.Context().SetValue(contextKeyTUIStarted, true)
+		metrics.RecordConnect(player.ID)
+		return resumableModel{Model: model, playerID: player.ID, registry: registry},
+			[]tea.ProgramOption{tea.WithAltScreen()}
+	}
+}
+
+// sessionResumeMiddleware makes an interactive session (one with no exec
+// command) eligible for resume: it hands bubbleTeaHandler any session
+// registry retained from before a recent disconnect, and once the
+// connection ends, starts that session's grace period countdown on both
+// the retained UI state and its Engine. Cleanup runs from a defer, so a
+// panic unwinding out of next (e.g. a crash inside the bubbletea program)
+// still detaches the session and releases its Engine to keep saving and
+// auto-saving rather than leaking it for good.
+func sessionResumeMiddleware(registry *SessionRegistry, engines *EngineRegistry, metrics *Metrics) wish.Middleware {
+	return func(next cssh.Handler) cssh.Handler {
+		return func(sess cssh.Session) {
+			player, _ := sess.Context().Value(contextKeyPlayer).(*db.Player)
+			interactive := player != nil && len(sess.Command()) == 0
+
+			if interactive {
+				if model, ok := registry.Resume(player.ID); ok {
+					sess.Context().SetValue(contextKeyResumedModel, model)
+				}
+
+				defer func() {
+					if _, started := sess.Context().Value(contextKeyTUIStarted).(bool); started {
+						registry.Detach(player.ID)
+						engines.Release(player.ID)
+						metrics.RecordDisconnect(player.ID)
+					}
+				}()
+			}
+
+			next(sess)
+		}
+	}
+}
+
+// remoteIP extracts the bare IP from addr, so it can be checked against a
+// ConnectionFilter or ConnectionRateLimiter regardless of the connection's
+// source port.
+func remoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
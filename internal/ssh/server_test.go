@@ -0,0 +1,53 @@
+package ssh
+
+import (
+	"testing"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// resumeTestSession is a minimal cssh.Session stand-in exposing only the
+// Context and Command methods sessionResumeMiddleware needs.
+type resumeTestSession struct {
+	cssh.Session
+	ctx *testSSHContext
+}
+
+func (s *resumeTestSession) Context() cssh.Context { return s.ctx }
+func (s *resumeTestSession) Command() []string     { return nil }
+
+func TestSessionResumeMiddlewareDetachesOnPanic(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	registry := NewSessionRegistry(time.Minute)
+	engines := NewEngineRegistry(10 * time.Millisecond)
+	engine, _ := engines.Acquire(database, player, &db.GameState{PlayerID: player.ID, Realm: "main"})
+
+	middleware := sessionResumeMiddleware(registry, engines, NewMetrics())
+	handler := middleware(func(sess cssh.Session) {
+		sess.Context().SetValue(contextKeyTUIStarted, true)
+		panic("bubbletea program crashed")
+	})
+
+	sess := &resumeTestSession{ctx: &testSSHContext{values: map[interface{}]interface{}{contextKeyPlayer: player}}}
+
+	func() {
+		defer func() { recover() }()
+		handler(sess)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	reacquired, _ := engines.Acquire(database, player, &db.GameState{PlayerID: player.ID, Realm: "main"})
+	t.Cleanup(reacquired.Stop)
+	if reacquired == engine {
+		t.Error("EngineRegistry still holds the panicked session's Engine past its grace period, want Release to have run on panic")
+	}
+}
@@ -0,0 +1,48 @@
+// Package ssh implements the SSH gateway players connect to.
+package ssh
+
+import (
+	"sync/atomic"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// Session tracks a single player's SSH connection so it can be persisted
+// for "last seen from" info and abuse investigation.
+type Session struct {
+	PlayerID string
+	SourceIP string
+
+	db       db.Database
+	recordID int64
+	bytesIn  atomic.Int64
+	bytesOut atomic.Int64
+}
+
+// NewSession records the start of a connection and returns a Session that
+// tracks it until Close is called.
+func NewSession(database db.Database, playerID, sourceIP string) (*Session, error) {
+	id, err := database.StartSession(playerID, sourceIP)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		PlayerID: playerID,
+		SourceIP: sourceIP,
+		db:       database,
+		recordID: id,
+	}, nil
+}
+
+// AddBytes accumulates bytes transferred over the connection so far.
+func (s *Session) AddBytes(in, out int64) {
+	s.bytesIn.Add(in)
+	s.bytesOut.Add(out)
+}
+
+// Close records the end of the connection along with the total bytes
+// transferred.
+func (s *Session) Close() error {
+	return s.db.EndSession(s.recordID, s.bytesIn.Load(), s.bytesOut.Load())
+}
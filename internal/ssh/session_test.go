@@ -0,0 +1,43 @@
+package ssh
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestSessionRecordsBytesOnClose(t *testing.T) {
+	sdb, err := db.NewSQLiteDB(filepath.Join(t.TempDir(), "test.db"), db.DefaultOptions())
+	if err != nil {
+		t.Fatalf("NewSQLiteDB() error = %v", err)
+	}
+	defer sdb.Close()
+
+	if err := sdb.SavePlayer(&db.Player{ID: "p1", Username: "monkey"}); err != nil {
+		t.Fatalf("SavePlayer() error = %v", err)
+	}
+
+	session, err := NewSession(sdb, "p1", "203.0.113.5")
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+
+	session.AddBytes(10, 5)
+	session.AddBytes(3, 2)
+
+	if err := session.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rec, err := sdb.GetLastSession("p1")
+	if err != nil {
+		t.Fatalf("GetLastSession() error = %v", err)
+	}
+	if rec.BytesIn != 13 || rec.BytesOut != 7 {
+		t.Errorf("BytesIn/BytesOut = %d/%d, want 13/7", rec.BytesIn, rec.BytesOut)
+	}
+	if rec.EndedAt == nil {
+		t.Error("EndedAt = nil, want non-nil after Close()")
+	}
+}
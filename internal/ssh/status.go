@@ -0,0 +1,76 @@
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// statusPingTimeout bounds how long the status command waits on the
+// database health check, so a struggling DB shows up as "unreachable"
+// rather than hanging the command indefinitely.
+const statusPingTimeout = 2 * time.Second
+
+// isAdminFingerprint reports whether fp is one of the configured admin
+// key fingerprints, gating admin-only exec commands like status.
+func isAdminFingerprint(fingerprints []string, fp string) bool {
+	for _, f := range fingerprints {
+		if f == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyTOTPPrompt asks the connected client for their current TOTP code
+// and checks it against secret, for admins who have a second factor
+// configured in cfg.AdminTOTP. It returns an error if the code is wrong,
+// missing, or can't be read, e.g. because the client disconnected without
+// typing one.
+func verifyTOTPPrompt(sess cssh.Session, secret string) error {
+	fmt.Fprint(sess, "TOTP code: ")
+
+	scanner := bufio.NewScanner(sess)
+	if !scanner.Scan() {
+		return errors.New("no TOTP code entered")
+	}
+
+	if !totp.Validate(strings.TrimSpace(scanner.Text()), secret) {
+		return errors.New("invalid TOTP code")
+	}
+	return nil
+}
+
+// runStatusCommand prints a quick operational summary - active sessions,
+// server uptime, how many players have a production Engine running, and
+// whether the database is reachable - enough for an operator to sanity
+// check the server over SSH without standing up an HTTP dashboard.
+func runStatusCommand(w io.Writer, database db.Database, metrics *Metrics, engines *EngineRegistry) error {
+	snapshot := metrics.Snapshot()
+	fmt.Fprintf(w, "uptime: %s\n", metrics.Uptime().Round(time.Second))
+	fmt.Fprintf(w, "active_sessions: %d\n", snapshot.ActiveSessions)
+	fmt.Fprintf(w, "running_engines: %d\n", engines.Len())
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusPingTimeout)
+	defer cancel()
+	if err := database.Ping(ctx); err != nil {
+		fmt.Fprintf(w, "database: unreachable (%v)\n", err)
+	} else {
+		fmt.Fprintln(w, "database: ok")
+	}
+
+	stats := database.Stats()
+	fmt.Fprintf(w, "database_open_connections: %d\n", stats.OpenConnections)
+	fmt.Fprintf(w, "database_in_use: %d\n", stats.InUse)
+
+	return nil
+}
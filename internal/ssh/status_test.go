@@ -0,0 +1,104 @@
+package ssh
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	cssh "github.com/charmbracelet/ssh"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestRunStatusCommandPrintsOperationalSummary(t *testing.T) {
+	database := newTestDB(t)
+	player, err := Register(database, "monkey", "main", newTestPublicKey(t))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	metrics := NewMetrics()
+	metrics.RecordConnect(player.ID)
+
+	engines := NewEngineRegistry(time.Minute)
+	engine, _ := engines.Acquire(database, player, &db.GameState{PlayerID: player.ID, Realm: "main"})
+	t.Cleanup(engine.Stop)
+
+	var buf bytes.Buffer
+	if err := runStatusCommand(&buf, database, metrics, engines); err != nil {
+		t.Fatalf("runStatusCommand() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "active_sessions: 1") {
+		t.Errorf("output = %q, want it to mention one active session", out)
+	}
+	if !strings.Contains(out, "running_engines: 1") {
+		t.Errorf("output = %q, want it to mention one running engine", out)
+	}
+	if !strings.Contains(out, "database: ok") {
+		t.Errorf("output = %q, want it to report the database as reachable", out)
+	}
+}
+
+func TestIsAdminFingerprintMatchesConfiguredList(t *testing.T) {
+	admins := []string{"SHA256:abc123", "SHA256:def456"}
+
+	if !isAdminFingerprint(admins, "SHA256:abc123") {
+		t.Error("isAdminFingerprint() = false, want true for a fingerprint in the list")
+	}
+	if isAdminFingerprint(admins, "SHA256:other") {
+		t.Error("isAdminFingerprint() = true, want false for a fingerprint not in the list")
+	}
+	if isAdminFingerprint(nil, "SHA256:abc123") {
+		t.Error("isAdminFingerprint() = true, want false with no admins configured")
+	}
+}
+
+func TestVerifyTOTPPromptAcceptsCurrentCode(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("totp.GenerateCode() error = %v", err)
+	}
+
+	sess := &totpPromptSession{input: strings.NewReader(code + "\n")}
+	if err := verifyTOTPPrompt(sess, secret); err != nil {
+		t.Fatalf("verifyTOTPPrompt() error = %v, want nil for a current code", err)
+	}
+	if !strings.Contains(sess.output.String(), "TOTP code:") {
+		t.Errorf("output = %q, want a prompt for the TOTP code", sess.output.String())
+	}
+}
+
+func TestVerifyTOTPPromptRejectsWrongCode(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	sess := &totpPromptSession{input: strings.NewReader("000000\n")}
+	if err := verifyTOTPPrompt(sess, secret); err == nil {
+		t.Fatal("verifyTOTPPrompt() error = nil, want an error for a wrong code")
+	}
+}
+
+func TestVerifyTOTPPromptRejectsNoInput(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	sess := &totpPromptSession{input: strings.NewReader("")}
+	if err := verifyTOTPPrompt(sess, secret); err == nil {
+		t.Fatal("verifyTOTPPrompt() error = nil, want an error when the client sends nothing")
+	}
+}
+
+// totpPromptSession is a minimal cssh.Session stand-in that reads the
+// client's typed code from input and captures everything written to it,
+// for exercising verifyTOTPPrompt without a real SSH connection.
+type totpPromptSession struct {
+	cssh.Session
+	input  *strings.Reader
+	output bytes.Buffer
+}
+
+func (s *totpPromptSession) Read(p []byte) (int, error)  { return s.input.Read(p) }
+func (s *totpPromptSession) Write(p []byte) (int, error) { return s.output.Write(p) }
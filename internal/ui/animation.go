@@ -0,0 +1,28 @@
+package ui
+
+// monkeyAnimationFrames are the frames a small ASCII "monkey typing"
+// animation cycles through, advanced once per historyTick so the game
+// view has something visibly alive even between milestones.
+var monkeyAnimationFrames = []string{
+	"(o_o) @/    typing",
+	"(o_O)  /    typing.",
+	"(O_o)   \\   typing..",
+	"(o_o)    \\  typing...",
+}
+
+// typingAnimationModel cycles through monkeyAnimationFrames, giving the
+// production tick a small piece of motion to render each time it fires.
+type typingAnimationModel struct {
+	frame int
+}
+
+// Advance moves to the next animation frame, wrapping back to the first
+// once the last one's been shown.
+func (m *typingAnimationModel) Advance() {
+	m.frame = (m.frame + 1) % len(monkeyAnimationFrames)
+}
+
+// View renders the current animation frame.
+func (m typingAnimationModel) View() string {
+	return monkeyAnimationFrames[m.frame]
+}
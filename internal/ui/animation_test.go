@@ -0,0 +1,26 @@
+package ui
+
+import "testing"
+
+func TestTypingAnimationModelAdvanceCyclesFrames(t *testing.T) {
+	var m typingAnimationModel
+	first := m.View()
+
+	for range monkeyAnimationFrames {
+		m.Advance()
+	}
+
+	if got := m.View(); got != first {
+		t.Errorf("View() = %q after a full cycle, want it back to %q", got, first)
+	}
+}
+
+func TestTypingAnimationModelAdvanceChangesFrame(t *testing.T) {
+	var m typingAnimationModel
+	before := m.View()
+	m.Advance()
+
+	if got := m.View(); got == before {
+		t.Errorf("View() = %q, want a different frame after Advance()", got)
+	}
+}
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// renderDashboard draws the at-a-glance dashboard: overall production
+// rate, progress toward the next milestone, and story progression as the
+// closest thing this game has to quest tracking. GameState only tracks a
+// single ProductionRate total rather than a per-source breakdown, and
+// there's no buff system anywhere in the game package, so both are
+// disclosed as such rather than faked.
+func renderDashboard(state *db.GameState, catalog Catalog) string {
+	sections := []string{
+		catalog.T("dashboard.kps", FormatNumber(state.ProductionRate, false)),
+		catalog.T("dashboard.buffs"),
+		"",
+		catalog.T("dashboard.milestones"),
+		renderMilestones(state, catalog),
+		"",
+		catalog.T("dashboard.quest", progressBar(game.StoryProgressPercent(state.CurrentLevel)/100), game.StoryProgressPercent(state.CurrentLevel)),
+	}
+	return strings.Join(sections, "\n")
+}
@@ -0,0 +1,19 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestRenderDashboardIncludesProductionMilestonesAndQuestProgress(t *testing.T) {
+	state := &db.GameState{CurrentLevel: 10, ProductionRate: 3.5, Keystrokes: 50, Words: 3, Programs: 2}
+	got := renderDashboard(state, NewCatalog(LocaleEN))
+
+	for _, want := range []string{"Production:", "Active buffs:", "Next milestone:", "Next word:", "Story progress:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderDashboard() = %q, want it to include %q", got, want)
+		}
+	}
+}
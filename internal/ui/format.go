@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// numberSuffixes maps each order of magnitude FormatNumber abbreviates to
+// its suffix, largest first, so it picks the biggest one that still
+// leaves at least one whole digit.
+var numberSuffixes = []struct {
+	threshold float64
+	suffix    string
+}{
+	{1e12, "T"},
+	{1e9, "B"},
+	{1e6, "M"},
+	{1e3, "K"},
+}
+
+// FormatNumber renders n the way the game's resource, cost, and
+// leaderboard displays do: plain below 1000, and abbreviated with a
+// suffix above it (1.23M, 4.5B) so large idle-game totals stay readable
+// instead of printing as a raw float. If scientific is true, n is
+// rendered in scientific notation instead (1.23e+06), for players who'd
+// rather read exponents than suffixes.
+func FormatNumber(n float64, scientific bool) string {
+	if scientific {
+		return fmt.Sprintf("%.2e", n)
+	}
+
+	abs := math.Abs(n)
+	for _, s := range numberSuffixes {
+		if abs >= s.threshold {
+			return trimTrailingZeros(n/s.threshold, 2) + s.suffix
+		}
+	}
+	return trimTrailingZeros(n, 0)
+}
+
+// trimTrailingZeros formats n to at most decimals digits after the point,
+// dropping trailing zeros (and a trailing point) so 1.50M reads as 1.5M.
+func trimTrailingZeros(n float64, decimals int) string {
+	s := strconv.FormatFloat(n, 'f', decimals, 64)
+	if decimals == 0 {
+		return s
+	}
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
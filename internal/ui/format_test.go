@@ -0,0 +1,39 @@
+package ui
+
+import "testing"
+
+func TestFormatNumberBelowThousandIsPlain(t *testing.T) {
+	if got := FormatNumber(42, false); got != "42" {
+		t.Errorf("FormatNumber(42) = %q, want %q", got, "42")
+	}
+}
+
+func TestFormatNumberAbbreviatesWithSuffix(t *testing.T) {
+	tests := []struct {
+		n    float64
+		want string
+	}{
+		{1234567.8, "1.23M"},
+		{4500000000, "4.5B"},
+		{2500, "2.5K"},
+		{1000, "1K"},
+		{1_000_000_000_000, "1T"},
+	}
+	for _, tt := range tests {
+		if got := FormatNumber(tt.n, false); got != tt.want {
+			t.Errorf("FormatNumber(%v) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNumberPreservesSign(t *testing.T) {
+	if got := FormatNumber(-1234567, false); got != "-1.23M" {
+		t.Errorf("FormatNumber(-1234567) = %q, want %q", got, "-1.23M")
+	}
+}
+
+func TestFormatNumberScientificNotation(t *testing.T) {
+	if got := FormatNumber(1234567.8, true); got != "1.23e+06" {
+		t.Errorf("FormatNumber(1234567.8, true) = %q, want %q", got, "1.23e+06")
+	}
+}
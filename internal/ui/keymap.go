@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines every keybinding the game view responds to. It's built
+// with bubbles/key so each binding carries its own help text, which the
+// help overlay renders directly instead of a separately maintained hint
+// string.
+type KeyMap struct {
+	Quit                key.Binding
+	Up                  key.Binding
+	Down                key.Binding
+	CycleBuyMode        key.Binding
+	PurchaseUpgrade     key.Binding
+	ToggleAccessible    key.Binding
+	ToggleHelp          key.Binding
+	ToggleNotifications key.Binding
+	ToggleStory         key.Binding
+	Save                key.Binding
+	ToggleLeaderboard   key.Binding
+	ToggleLowBandwidth  key.Binding
+	ToggleDashboard     key.Binding
+}
+
+// DefaultKeyMap is the KeyMap every session starts with.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "disconnect"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "browse upgrades up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "browse upgrades down"),
+		),
+		CycleBuyMode: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "cycle buy mode"),
+		),
+		PurchaseUpgrade: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "purchase selected upgrade"),
+		),
+		ToggleAccessible: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "toggle accessibility mode"),
+		),
+		ToggleHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle this help"),
+		),
+		ToggleNotifications: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "toggle notification history"),
+		),
+		ToggleStory: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "browse story chapters"),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "save now"),
+		),
+		ToggleLeaderboard: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "view leaderboard"),
+		),
+		ToggleLowBandwidth: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "toggle low-bandwidth mode"),
+		),
+		ToggleDashboard: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "toggle dashboard"),
+		),
+	}
+}
+
+// KeyBindingOverrides maps an action name to the key(s) that should
+// trigger it, keyed the same way a config file identifies each binding:
+// "quit", "up", "down", "cycle_buy_mode", "purchase_upgrade",
+// "toggle_accessible", "toggle_help", "toggle_notifications",
+// "toggle_story", "save", "toggle_leaderboard", "toggle_low_bandwidth", or
+// "toggle_dashboard". An action left out of the map keeps its default
+// keys.
+type KeyBindingOverrides map[string][]string
+
+// LoadKeyMap builds a KeyMap starting from DefaultKeyMap and replacing
+// any binding named in overrides, both the keys that trigger it and the
+// label the help overlay shows for it, so the two never drift apart. An
+// action name LoadKeyMap doesn't recognize, or one mapped to no keys, is
+// ignored rather than failing the whole config.
+func LoadKeyMap(overrides KeyBindingOverrides) KeyMap {
+	keys := DefaultKeyMap()
+	for action, newKeys := range overrides {
+		if len(newKeys) == 0 {
+			continue
+		}
+		switch action {
+		case "quit":
+			keys.Quit = rebind(keys.Quit, newKeys)
+		case "up":
+			keys.Up = rebind(keys.Up, newKeys)
+		case "down":
+			keys.Down = rebind(keys.Down, newKeys)
+		case "cycle_buy_mode":
+			keys.CycleBuyMode = rebind(keys.CycleBuyMode, newKeys)
+		case "purchase_upgrade":
+			keys.PurchaseUpgrade = rebind(keys.PurchaseUpgrade, newKeys)
+		case "toggle_accessible":
+			keys.ToggleAccessible = rebind(keys.ToggleAccessible, newKeys)
+		case "toggle_help":
+			keys.ToggleHelp = rebind(keys.ToggleHelp, newKeys)
+		case "toggle_notifications":
+			keys.ToggleNotifications = rebind(keys.ToggleNotifications, newKeys)
+		case "toggle_story":
+			keys.ToggleStory = rebind(keys.ToggleStory, newKeys)
+		case "save":
+			keys.Save = rebind(keys.Save, newKeys)
+		case "toggle_leaderboard":
+			keys.ToggleLeaderboard = rebind(keys.ToggleLeaderboard, newKeys)
+		case "toggle_low_bandwidth":
+			keys.ToggleLowBandwidth = rebind(keys.ToggleLowBandwidth, newKeys)
+		case "toggle_dashboard":
+			keys.ToggleDashboard = rebind(keys.ToggleDashboard, newKeys)
+		}
+	}
+	return keys
+}
+
+// rebind replaces b's trigger keys with newKeys, relabeling its help
+// text to match so a remapped binding never shows its old key in the
+// help overlay.
+func rebind(b key.Binding, newKeys []string) key.Binding {
+	return key.NewBinding(
+		key.WithKeys(newKeys...),
+		key.WithHelp(strings.Join(newKeys, "/"), b.Help().Desc),
+	)
+}
+
+// ShortHelp implements help.KeyMap, returning the bindings shown in the
+// one-line hint at the bottom of the game view.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.ToggleHelp, k.Quit}
+}
+
+// FullHelp implements help.KeyMap, returning every binding grouped for
+// the full overlay ToggleHelp opens.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.CycleBuyMode, k.PurchaseUpgrade},
+		{k.ToggleAccessible, k.ToggleNotifications},
+		{k.ToggleStory, k.ToggleLeaderboard},
+		{k.Save, k.ToggleDashboard},
+		{k.ToggleHelp, k.Quit},
+	}
+}
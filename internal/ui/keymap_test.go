@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultKeyMapFullHelpCoversEveryBinding(t *testing.T) {
+	keys := DefaultKeyMap()
+	groups := keys.FullHelp()
+
+	count := 0
+	for _, group := range groups {
+		count += len(group)
+	}
+	if want := 12; count != want {
+		t.Errorf("FullHelp() lists %d bindings, want %d", count, want)
+	}
+}
+
+func TestLoadKeyMapAppliesOverride(t *testing.T) {
+	keys := LoadKeyMap(KeyBindingOverrides{"cycle_buy_mode": {"x"}})
+
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}, keys.CycleBuyMode) {
+		t.Error("CycleBuyMode does not match the remapped key 'x'")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")}, keys.CycleBuyMode) {
+		t.Error("CycleBuyMode still matches the default key 'b' after being remapped")
+	}
+	if got := keys.CycleBuyMode.Help().Key; got != "x" {
+		t.Errorf("CycleBuyMode.Help().Key = %q, want %q so the help overlay matches the remapped key", got, "x")
+	}
+}
+
+func TestLoadKeyMapIgnoresUnknownActionAndEmptyKeys(t *testing.T) {
+	keys := LoadKeyMap(KeyBindingOverrides{
+		"not_a_real_action": {"z"},
+		"quit":              {},
+	})
+
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, keys.Quit) {
+		t.Error("Quit should keep its default key when overridden with an empty key list")
+	}
+}
+
+func TestLoadKeyMapLeavesUnmentionedBindingsAtDefault(t *testing.T) {
+	keys := LoadKeyMap(KeyBindingOverrides{"cycle_buy_mode": {"x"}})
+	def := DefaultKeyMap()
+
+	if keys.Quit.Help().Key != def.Quit.Help().Key {
+		t.Errorf("Quit.Help().Key = %q, want it unchanged at %q", keys.Quit.Help().Key, def.Quit.Help().Key)
+	}
+}
+
+func TestDefaultKeyMapShortHelpIncludesQuit(t *testing.T) {
+	keys := DefaultKeyMap()
+	short := keys.ShortHelp()
+
+	found := false
+	for _, b := range short {
+		if b.Help().Key == keys.Quit.Help().Key {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ShortHelp() = %+v, want it to include Quit", short)
+	}
+}
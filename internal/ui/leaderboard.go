@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// leaderboardPageSize is how many ranks a page of the leaderboard panel
+// shows at once.
+const leaderboardPageSize = 10
+
+// leaderboardPanelModel renders a page of the realm leaderboard, paging
+// between ranks and highlighting the viewing player's own row so they
+// can find themselves without counting down the list.
+type leaderboardPanelModel struct {
+	entries  []*db.LeaderboardEntry
+	offset   int
+	playerID string
+	styles   Styles
+	catalog  Catalog
+}
+
+// newLeaderboardPanelModel builds an empty leaderboardPanelModel,
+// rendering rows with styles and text with catalog.
+func newLeaderboardPanelModel(styles Styles, catalog Catalog) leaderboardPanelModel {
+	return leaderboardPanelModel{styles: styles, catalog: catalog}
+}
+
+// SetPlayer records which player's row should be highlighted.
+func (m *leaderboardPanelModel) SetPlayer(playerID string) {
+	m.playerID = playerID
+}
+
+// SetEntries replaces the panel's current page with entries.
+func (m *leaderboardPanelModel) SetEntries(entries []*db.LeaderboardEntry) {
+	m.entries = entries
+}
+
+// SetStyles switches the palette rows render with, e.g. when the player
+// toggles accessibility mode.
+func (m *leaderboardPanelModel) SetStyles(styles Styles) {
+	m.styles = styles
+}
+
+// Offset returns the rank offset of the panel's current page.
+func (m leaderboardPanelModel) Offset() int {
+	return m.offset
+}
+
+// Update handles paging between ranks, reporting whether the offset
+// changed so the caller knows to fetch the new page.
+func (m leaderboardPanelModel) Update(msg tea.Msg) (leaderboardPanelModel, bool) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, false
+	}
+	switch keyMsg.String() {
+	case "left", "h":
+		if m.offset < leaderboardPageSize {
+			return m, false
+		}
+		m.offset -= leaderboardPageSize
+		return m, true
+	case "right", "l":
+		m.offset += leaderboardPageSize
+		return m, true
+	}
+	return m, false
+}
+
+// View draws the current page, ranked and severity-free but with the
+// viewing player's row accented, or a message when the page is empty
+// (e.g. paged past the end of the leaderboard).
+func (m leaderboardPanelModel) View() string {
+	if len(m.entries) == 0 {
+		return m.catalog.T("leaderboard.empty", m.offset+1, m.offset+leaderboardPageSize)
+	}
+
+	lines := make([]string, len(m.entries))
+	for i, e := range m.entries {
+		row := fmt.Sprintf("%3d. %-16s %10s keystrokes  %6s words  %4s programs",
+			m.offset+i+1, e.Username,
+			FormatNumber(e.Keystrokes, false),
+			FormatNumber(float64(e.Words), false),
+			FormatNumber(float64(e.Programs), false))
+		if e.PlayerID == m.playerID {
+			row = m.styles.Accent.Render(row)
+		}
+		lines[i] = row
+	}
+	lines = append(lines, m.catalog.T("leaderboard.footer", m.offset+1, m.offset+len(m.entries)))
+	return strings.Join(lines, "\n")
+}
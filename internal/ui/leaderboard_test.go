@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestLeaderboardPanelViewHighlightsCurrentPlayer(t *testing.T) {
+	m := newLeaderboardPanelModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN))
+	m.SetPlayer("p2")
+	m.SetEntries([]*db.LeaderboardEntry{
+		{PlayerID: "p1", Username: "alice", Keystrokes: 100},
+		{PlayerID: "p2", Username: "bob", Keystrokes: 50},
+	})
+
+	view := m.View()
+	if !strings.Contains(view, "alice") || !strings.Contains(view, "bob") {
+		t.Fatalf("View() = %q, want both entries listed", view)
+	}
+}
+
+func TestLeaderboardPanelViewReportsEmptyPage(t *testing.T) {
+	m := newLeaderboardPanelModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN))
+
+	if got := m.View(); !strings.Contains(got, "No entries") {
+		t.Errorf("View() = %q, want an empty-page message", got)
+	}
+}
+
+func TestLeaderboardPanelUpdatePagesRight(t *testing.T) {
+	m := newLeaderboardPanelModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN))
+
+	m, changed := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if !changed {
+		t.Fatal("Update('l') changed = false, want true")
+	}
+	if m.Offset() != leaderboardPageSize {
+		t.Errorf("Offset() = %d, want %d after paging right", m.Offset(), leaderboardPageSize)
+	}
+}
+
+func TestLeaderboardPanelUpdateWontPageLeftPastZero(t *testing.T) {
+	m := newLeaderboardPanelModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN))
+
+	m, changed := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if changed {
+		t.Error("Update('h') changed = true at offset 0, want false")
+	}
+	if m.Offset() != 0 {
+		t.Errorf("Offset() = %d, want 0", m.Offset())
+	}
+}
+
+func TestLeaderboardPanelUpdatePagesLeftBackToZero(t *testing.T) {
+	m := newLeaderboardPanelModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN))
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+
+	m, changed := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if !changed {
+		t.Fatal("Update('h') changed = false, want true")
+	}
+	if m.Offset() != 0 {
+		t.Errorf("Offset() = %d, want 0", m.Offset())
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import "fmt"
+
+// Locale selects which language Catalog strings render in. LocaleEN is
+// the baseline every message is guaranteed to have; other locales only
+// need to provide the subset they've translated, since NewCatalog falls
+// back to LocaleEN for anything missing.
+type Locale string
+
+const (
+	LocaleEN Locale = "en"
+	LocaleES Locale = "es"
+)
+
+// messages holds every locale's translated strings, keyed by message
+// key. Adding a locale here, and a handful of entries to it, is all a
+// translation needs - no other file has to change.
+var messages = map[Locale]map[string]string{
+	LocaleEN: {
+		"status.welcome":                 "Welcome back, %s",
+		"status.progress":                "Level %d  |  %s keystrokes  |  %s words  |  %s programs",
+		"status.buy_mode":                "Buy mode: %s",
+		"status.progression":             "Progression: %s",
+		"status.burst":                   "+%s!",
+		"status.story":                   "Story: Chapter %d - %s",
+		"upgrades.heading":               "Upgrades:",
+		"upgrades.no_match":              "No upgrades match.",
+		"upgrades.category.production":   "Production",
+		"upgrades.category.automation":   "Automation",
+		"upgrades.category.story":        "Story",
+		"upgrades.detail.current_effect": "Current effect: +%s kps",
+		"upgrades.detail.next_effect":    "Next effect:    +%s kps",
+		"upgrades.detail.next_level":     "Next level:     %s",
+		"upgrades.detail.payback":        "Payback time:   %s",
+		"upgrades.detail.total_spent":    "Total spent:    %s",
+		"leaderboard.heading":            "Leaderboard:",
+		"leaderboard.footer":             "Ranks %d-%d (left/right to page)",
+		"leaderboard.empty":              "No entries for ranks %d-%d.",
+		"progress.word":                  "Next word:    %s %3.0f%%",
+		"progress.program":               "Next program: %s %3.0f%%",
+		"progress.ai":                    "Next AI:      %s %3.0f%%",
+		"offline.summary":                "While you were away (%s):\n  +%s keystrokes\n\nPress any key to continue\n",
+		"notifications.heading":          "Notification history:",
+		"story.heading":                  "Story chapters:",
+		"resize.prompt":                  "Terminal too small (%dx%d).\nPlease resize to at least %dx%d and the game will resume.\n",
+		"dashboard.heading":              "Dashboard:",
+		"dashboard.kps":                  "Production: %s kps (no per-source breakdown tracked yet)",
+		"dashboard.buffs":                "Active buffs: none",
+		"dashboard.milestones":           "Next milestone:",
+		"dashboard.quest":                "Story progress: %s %3.0f%%",
+	},
+	LocaleES: {
+		"status.welcome":               "Bienvenido de nuevo, %s",
+		"status.progress":              "Nivel %d  |  %s pulsaciones  |  %s palabras  |  %s programas",
+		"status.buy_mode":              "Modo de compra: %s",
+		"status.progression":           "Progresión: %s",
+		"status.burst":                 "+%s!",
+		"status.story":                 "Historia: Capítulo %d - %s",
+		"upgrades.heading":             "Mejoras:",
+		"upgrades.no_match":            "No hay mejoras que coincidan.",
+		"upgrades.category.production": "Producción",
+		"upgrades.category.automation": "Automatización",
+		"upgrades.category.story":      "Historia",
+		"leaderboard.heading":          "Clasificación:",
+		"leaderboard.footer":           "Puestos %d-%d (izquierda/derecha para cambiar de página)",
+		"leaderboard.empty":            "No hay entradas para los puestos %d-%d.",
+		"notifications.heading":        "Historial de notificaciones:",
+		"story.heading":                "Capítulos de la historia:",
+	},
+}
+
+// Catalog looks up message strings for a single locale, so the rest of
+// the ui package can render player-facing text without a language
+// baked directly into its format strings.
+type Catalog struct {
+	locale Locale
+}
+
+// NewCatalog builds a Catalog for locale, falling back to LocaleEN for
+// a locale with no entries of its own, so an empty or misconfigured
+// value never breaks message lookup.
+func NewCatalog(locale Locale) Catalog {
+	if _, ok := messages[locale]; !ok {
+		locale = LocaleEN
+	}
+	return Catalog{locale: locale}
+}
+
+// T formats the message named key with args, the way fmt.Sprintf would,
+// using the Catalog's locale if it has a translation for key and
+// LocaleEN otherwise. An unknown key is returned unchanged, so a typo'd
+// key shows up visibly in the UI instead of silently rendering empty.
+func (c Catalog) T(key string, args ...interface{}) string {
+	if msg, ok := messages[c.locale][key]; ok {
+		return fmt.Sprintf(msg, args...)
+	}
+	if msg, ok := messages[LocaleEN][key]; ok {
+		return fmt.Sprintf(msg, args...)
+	}
+	return key
+}
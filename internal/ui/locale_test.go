@@ -0,0 +1,36 @@
+package ui
+
+import "testing"
+
+func TestCatalogTFormatsTranslatedMessage(t *testing.T) {
+	en := NewCatalog(LocaleEN)
+	if got := en.T("upgrades.no_match"); got != "No upgrades match." {
+		t.Errorf("T(\"upgrades.no_match\") = %q, want %q", got, "No upgrades match.")
+	}
+
+	es := NewCatalog(LocaleES)
+	if got := es.T("upgrades.no_match"); got != "No hay mejoras que coincidan." {
+		t.Errorf("T(\"upgrades.no_match\") = %q, want %q", got, "No hay mejoras que coincidan.")
+	}
+}
+
+func TestCatalogTFallsBackToEnglishForUntranslatedKey(t *testing.T) {
+	es := NewCatalog(LocaleES)
+	if got := es.T("upgrades.detail.payback", "10s"); got != "Payback time:   10s" {
+		t.Errorf("T(\"upgrades.detail.payback\") = %q, want it to fall back to the English message", got)
+	}
+}
+
+func TestCatalogTReturnsKeyUnchangedWhenUnknown(t *testing.T) {
+	en := NewCatalog(LocaleEN)
+	if got := en.T("nonexistent.key"); got != "nonexistent.key" {
+		t.Errorf("T(\"nonexistent.key\") = %q, want the key itself", got)
+	}
+}
+
+func TestNewCatalogFallsBackToEnglishForUnrecognizedLocale(t *testing.T) {
+	c := NewCatalog(Locale("fr"))
+	if got := c.T("upgrades.no_match"); got != "No upgrades match." {
+		t.Errorf("T(\"upgrades.no_match\") = %q, want the English default for an unrecognized locale", got)
+	}
+}
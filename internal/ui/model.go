@@ -0,0 +1,484 @@
+// Package ui implements the terminal interface players interact with over
+// SSH, built on Bubble Tea.
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/maker2413/TermIdle/internal/db"
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// minWidth and minHeight are the smallest terminal dimensions the game
+// view renders at. Below either, View shows a resize prompt instead, so a
+// tiny terminal sees a clear message rather than a garbled layout.
+const (
+	minWidth  = 80
+	minHeight = 24
+)
+
+// wideLayoutWidth is the terminal width at which the game view switches
+// from a single stacked column to the status summary and upgrade list
+// side by side, so a wide terminal puts its extra columns to use instead
+// of leaving them blank.
+const wideLayoutWidth = 100
+
+// layoutColumnGap separates the two columns of a wide layout.
+const layoutColumnGap = "   "
+
+// historyTickInterval is how often the game view refreshes itself while a
+// history func is configured, so the production sparkline (and everything
+// else in the view) stays current rather than showing whatever was true
+// when the session connected.
+const historyTickInterval = 1 * time.Second
+
+// Model is the top-level Bubble Tea model for a player's session. It
+// renders their current progress and reacts to terminal resizes so it
+// never assumes a fixed window size.
+type Model struct {
+	player *db.Player
+	state  *db.GameState
+	banner string
+
+	buyMode    game.BuyMode
+	upgrades   upgradeListModel
+	styles     Styles
+	catalog    Catalog
+	accessible bool
+
+	// lowBandwidth trades a plainer palette and less frequent periodic
+	// redraws for less data sent per frame, for a player on a slow SSH
+	// link. Toggled per session; it doesn't affect anyone else connected.
+	lowBandwidth bool
+
+	keys     KeyMap
+	help     help.Model
+	showHelp bool
+
+	history        func() []float64
+	animation      typingAnimationModel
+	lastKeystrokes float64
+	burst          string
+
+	notifications     func() []*db.Notification
+	notificationPanel notificationPanelModel
+	showNotifications bool
+
+	story     storyBrowserModel
+	showStory bool
+
+	offline     OfflineEarnings
+	showOffline bool
+
+	toasts toastTrayModel
+
+	leaderboard      func(offset, limit int) []*db.LeaderboardEntry
+	leaderboardPanel leaderboardPanelModel
+	showLeaderboard  bool
+
+	showDashboard bool
+
+	save func() error
+
+	purchase func(upgradeType string, level int, cost float64) error
+
+	width  int
+	height int
+}
+
+// NewModelWithAll builds a Model for player's session, starting from state
+// and showing banner (e.g. a message-of-the-day) before the game view,
+// rendered with theme's color palette, localized with catalog, and
+// responding to keys. history, if non-nil, is polled periodically to
+// render a production sparkline; pass nil to disable it. notifications,
+// if non-nil, is polled periodically to surface new notifications as
+// fading toasts (and each time the notification panel is opened, for the
+// full history); pass nil to disable it. offline, if its Keystrokes is
+// non-zero, is shown once as a dismissible summary before the game view,
+// so a reconnecting player sees what changed while they were away
+// instead of their numbers just being different. leaderboard, if
+// non-nil, is called with a rank offset and page size each time the
+// leaderboard panel is opened, paged, or auto-refreshed; pass nil to
+// disable it. save, if non-nil, is called to persist progress
+// immediately when the player presses the save key and once more before
+// the session quits, so at most an interval's worth of progress is ever
+// at risk rather than whatever was last auto-saved. purchase, if non-nil,
+// is called with the upgrade type, new level, and cost each time the
+// player buys an upgrade, so the caller can persist the purchase
+// alongside the rest of state's progress.
+func NewModelWithAll(player *db.Player, state *db.GameState, banner string, theme Theme, keys KeyMap, catalog Catalog, history func() []float64, notifications func() []*db.Notification, offline OfflineEarnings, leaderboard func(offset, limit int) []*db.LeaderboardEntry, save func() error, purchase func(upgradeType string, level int, cost float64) error) Model {
+	styles := StylesFor(theme)
+	return Model{
+		player:            player,
+		state:             state,
+		lastKeystrokes:    state.Keystrokes,
+		banner:            banner,
+		styles:            styles,
+		catalog:           catalog,
+		upgrades:          newUpgradeListModel(styles, catalog, state.UpgradeLevels),
+		keys:              keys,
+		help:              help.New(),
+		history:           history,
+		notifications:     notifications,
+		notificationPanel: newNotificationPanelModel(styles),
+		story:             newStoryBrowserModel(styles),
+		offline:           offline,
+		showOffline:       offline.Keystrokes != 0,
+		toasts:            newToastTrayModel(),
+		leaderboard:       leaderboard,
+		leaderboardPanel:  newLeaderboardPanelModel(styles, catalog),
+		save:              save,
+		purchase:          purchase,
+	}
+}
+
+// quit persists the player's progress one last time, if a save func is
+// configured, before quitting the program.
+func (m Model) quit() (tea.Model, tea.Cmd) {
+	if m.save != nil {
+		_ = m.save()
+	}
+	return m, tea.Quit
+}
+
+// lowBandwidthTickMultiplier stretches every periodic tick interval by
+// this factor in low-bandwidth mode, so a slow link spends bytes on a
+// fresh frame far less often instead of every second regardless of
+// whether anything worth redrawing has happened.
+const lowBandwidthTickMultiplier = 4
+
+// tickInterval returns interval, stretched by lowBandwidthTickMultiplier
+// while m.lowBandwidth is set.
+func (m Model) tickInterval(interval time.Duration) time.Duration {
+	if m.lowBandwidth {
+		return interval * lowBandwidthTickMultiplier
+	}
+	return interval
+}
+
+// historyTickMsg is sent on a recurring timer to force the view to
+// re-render while a history func is configured.
+type historyTickMsg struct{}
+
+// historyTick schedules the next historyTickMsg.
+func (m Model) historyTick() tea.Cmd {
+	return tea.Tick(m.tickInterval(historyTickInterval), func(time.Time) tea.Msg {
+		return historyTickMsg{}
+	})
+}
+
+// toastTickMsg is sent on a recurring timer to expire stale toasts and
+// pick up newly arrived notifications while a notifications func is
+// configured, so events surface on their own instead of only when the
+// player opens the notification panel.
+type toastTickMsg struct{}
+
+// toastTick schedules the next toastTickMsg.
+func (m Model) toastTick() tea.Cmd {
+	return tea.Tick(m.tickInterval(historyTickInterval), func(time.Time) tea.Msg {
+		return toastTickMsg{}
+	})
+}
+
+// leaderboardTickInterval is how often the leaderboard panel refreshes
+// its current page while it's open, so ranks climbing in real time show
+// up without the player having to close and reopen the panel.
+const leaderboardTickInterval = 5 * time.Second
+
+// leaderboardTickMsg is sent on a recurring timer, while the leaderboard
+// panel is open, to refresh its current page.
+type leaderboardTickMsg struct{}
+
+// leaderboardTick schedules the next leaderboardTickMsg.
+func (m Model) leaderboardTick() tea.Cmd {
+	return tea.Tick(m.tickInterval(leaderboardTickInterval), func(time.Time) tea.Msg {
+		return leaderboardTickMsg{}
+	})
+}
+
+// Init implements tea.Model.
+func (m Model) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.history != nil {
+		cmds = append(cmds, m.historyTick())
+	}
+	if m.notifications != nil {
+		cmds = append(cmds, m.toastTick())
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update implements tea.Model.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case historyTickMsg:
+		m.animation.Advance()
+		if delta := m.state.Keystrokes - m.lastKeystrokes; delta > 0 {
+			m.burst = m.catalog.T("status.burst", FormatNumber(delta, false))
+		} else {
+			m.burst = ""
+		}
+		m.lastKeystrokes = m.state.Keystrokes
+		return m, m.historyTick()
+	case toastTickMsg:
+		m.toasts.Expire(time.Now())
+		if m.notifications != nil {
+			m.toasts.Notice(m.notifications(), time.Now())
+		}
+		return m, m.toastTick()
+	case leaderboardTickMsg:
+		if !m.showLeaderboard {
+			return m, nil
+		}
+		m.refreshLeaderboard()
+		return m, m.leaderboardTick()
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.upgrades.SetWidth(m.columnWidth())
+		m.notificationPanel.SetWidth(msg.Width)
+		m.story.SetWidth(msg.Width)
+		return m, nil
+	case tea.KeyMsg:
+		if m.showOffline {
+			if key.Matches(msg, m.keys.Quit) {
+				return m.quit()
+			}
+			m.showOffline = false
+			return m, nil
+		}
+		if m.upgrades.Filtering() {
+			m.upgrades = m.upgrades.Update(msg)
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m.quit()
+		case key.Matches(msg, m.keys.Save):
+			if m.save != nil {
+				_ = m.save()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleHelp):
+			m.showHelp = !m.showHelp
+			m.help.ShowAll = m.showHelp
+			return m, nil
+		case key.Matches(msg, m.keys.CycleBuyMode):
+			m.buyMode = game.NextBuyMode(m.buyMode)
+			return m, nil
+		case key.Matches(msg, m.keys.PurchaseUpgrade):
+			upgrades, upgradeType, level, cost, ok := m.upgrades.Purchase(m.buyMode, m.state.Keystrokes)
+			if ok {
+				m.upgrades = upgrades
+				m.state.Keystrokes -= cost
+				if m.purchase != nil {
+					_ = m.purchase(upgradeType, level, cost)
+				}
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleAccessible):
+			m.accessible = !m.accessible
+			m.upgrades.SetStyles(m.currentStyles())
+			m.notificationPanel.SetStyles(m.currentStyles())
+			m.story.SetStyles(m.currentStyles())
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleNotifications):
+			m.showNotifications = !m.showNotifications
+			if m.showNotifications && m.notifications != nil {
+				m.notificationPanel.SetNotifications(m.notifications())
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleStory):
+			m.showStory = !m.showStory
+			if m.showStory {
+				m.story.SetChapters(game.UnlockedChapters(m.state.CurrentLevel))
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleLeaderboard):
+			m.showLeaderboard = !m.showLeaderboard
+			if m.showLeaderboard {
+				m.leaderboardPanel.SetPlayer(m.player.ID)
+				m.refreshLeaderboard()
+				return m, m.leaderboardTick()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleLowBandwidth):
+			m.lowBandwidth = !m.lowBandwidth
+			m.upgrades.SetStyles(m.currentStyles())
+			m.notificationPanel.SetStyles(m.currentStyles())
+			m.story.SetStyles(m.currentStyles())
+			return m, nil
+		case key.Matches(msg, m.keys.ToggleDashboard):
+			m.showDashboard = !m.showDashboard
+			return m, nil
+		}
+		switch {
+		case m.showNotifications:
+			m.notificationPanel = m.notificationPanel.Update(msg)
+		case m.showStory:
+			m.story = m.story.Update(msg)
+		case m.showLeaderboard:
+			var pageChanged bool
+			m.leaderboardPanel, pageChanged = m.leaderboardPanel.Update(msg)
+			if pageChanged {
+				m.refreshLeaderboard()
+			}
+		default:
+			m.upgrades = m.upgrades.Update(msg)
+		}
+	}
+	return m, nil
+}
+
+// Size returns the terminal dimensions from the most recent
+// tea.WindowSizeMsg, or 0, 0 before the first one arrives.
+func (m Model) Size() (width, height int) {
+	return m.width, m.height
+}
+
+// refreshLeaderboard fetches the leaderboard panel's current page again,
+// a no-op if no leaderboard func is configured.
+func (m *Model) refreshLeaderboard() {
+	if m.leaderboard == nil {
+		return
+	}
+	m.leaderboardPanel.SetEntries(m.leaderboard(m.leaderboardPanel.Offset(), leaderboardPageSize))
+}
+
+// isWideLayout reports whether the terminal is wide enough to render the
+// status summary and upgrade list side by side rather than stacked.
+func (m Model) isWideLayout() bool {
+	return m.width >= wideLayoutWidth
+}
+
+// columnWidth returns how wide the upgrade list's column should be: half
+// the terminal (minus the gap between columns) in a wide layout, or the
+// full terminal width when stacked.
+func (m Model) columnWidth() int {
+	if m.isWideLayout() {
+		return (m.width - len(layoutColumnGap)) / 2
+	}
+	return m.width
+}
+
+// currentStyles returns AccessibleStyles when the player has toggled
+// accessibility mode or low-bandwidth mode, overriding whatever Theme
+// the session started with - low-bandwidth mode reuses it rather than a
+// style of its own since it's already the plainer, lighter-weight
+// palette a slow link benefits from - or the session's normal themed
+// styles otherwise.
+func (m Model) currentStyles() Styles {
+	if m.accessible || m.lowBandwidth {
+		return AccessibleStyles()
+	}
+	return m.styles
+}
+
+// View implements tea.Model.
+func (m Model) View() string {
+	if m.width > 0 && m.height > 0 && (m.width < minWidth || m.height < minHeight) {
+		return m.catalog.T("resize.prompt", m.width, m.height, minWidth, minHeight)
+	}
+
+	if m.showOffline {
+		return m.catalog.T("offline.summary", formatDuration(m.offline.Away), FormatNumber(m.offline.Keystrokes, false))
+	}
+
+	if m.showHelp {
+		return m.help.View(m.keys) + "\n"
+	}
+
+	if m.showNotifications {
+		return m.catalog.T("notifications.heading") + "\n" + m.notificationPanel.View() + "\n" + m.help.View(m.keys) + "\n"
+	}
+
+	if m.showStory {
+		return m.catalog.T("story.heading") + "\n" + m.story.View() + "\n" + m.help.View(m.keys) + "\n"
+	}
+
+	if m.showLeaderboard {
+		return m.catalog.T("leaderboard.heading") + "\n" + m.leaderboardPanel.View() + "\n" + m.help.View(m.keys) + "\n"
+	}
+
+	if m.showDashboard {
+		return m.catalog.T("dashboard.heading") + "\n" + renderDashboard(m.state, m.catalog) + "\n" + m.help.View(m.keys) + "\n"
+	}
+
+	styles := m.currentStyles()
+
+	view := ""
+	if m.banner != "" {
+		view += styles.Banner.Render(m.truncatedBanner()) + "\n\n"
+	}
+
+	status := m.catalog.T("status.welcome", m.player.Username) + "\n"
+	status += m.catalog.T("status.progress",
+		m.state.CurrentLevel,
+		FormatNumber(m.state.Keystrokes, false),
+		FormatNumber(float64(m.state.Words), false),
+		FormatNumber(float64(m.state.Programs), false)) + "\n"
+	status += styles.Accent.Render(m.catalog.T("status.buy_mode", m.buyMode)) + "\n"
+	if m.history != nil {
+		status += m.catalog.T("status.progression", Sparkline(m.history())) + "\n"
+		if !m.lowBandwidth {
+			status += m.animation.View()
+			if m.burst != "" {
+				status += "  " + styles.Accent.Render(m.burst)
+			}
+			status += "\n"
+		}
+	}
+	if toastsView := m.toasts.View(styles); toastsView != "" {
+		status += "\n" + toastsView + "\n"
+	}
+	if unlocked := game.UnlockedChapters(m.state.CurrentLevel); len(unlocked) > 0 {
+		current := unlocked[len(unlocked)-1]
+		status += m.catalog.T("status.story", current.ID, current.Title) + "\n"
+	}
+	status += "\n" + renderMilestones(m.state, m.catalog) + "\n"
+
+	upgrades := m.catalog.T("upgrades.heading") + "\n" + m.upgrades.View()
+
+	if m.isWideLayout() {
+		view += lipgloss.JoinHorizontal(lipgloss.Top, status, layoutColumnGap, upgrades)
+	} else {
+		view += status + "\n" + upgrades
+	}
+	view += "\n" + m.help.View(m.keys) + "\n"
+
+	return view
+}
+
+// truncatedBanner clips every line of the banner to the terminal's width,
+// so a message-of-the-day too long for a narrow terminal is visibly cut
+// off instead of wrapping and throwing off the rest of the layout.
+func (m Model) truncatedBanner() string {
+	if m.width <= 0 {
+		return m.banner
+	}
+	lines := strings.Split(m.banner, "\n")
+	for i, line := range lines {
+		lines[i] = truncateLine(line, m.width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// truncateLine clips s to at most width runes, appending an ellipsis in
+// place of the last rune when it doesn't fit.
+func truncateLine(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
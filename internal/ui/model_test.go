@@ -0,0 +1,502 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestModelViewShowsPlayerProgress(t *testing.T) {
+	m := NewModelWithAll(
+		&db.Player{Username: "monkey"},
+		&db.GameState{CurrentLevel: 3, Keystrokes: 1500, Words: 42, Programs: 1},
+		"welcome to term idle",
+		ThemeDefault,
+		DefaultKeyMap(),
+		NewCatalog(LocaleEN),
+		nil,
+		nil,
+		OfflineEarnings{},
+		nil,
+		nil,
+		nil,
+	)
+
+	view := m.View()
+	if !strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want it to mention the player's username", view)
+	}
+	if !strings.Contains(view, "welcome to term idle") {
+		t.Errorf("View() = %q, want it to include the banner", view)
+	}
+	if !strings.Contains(view, "Level 3") {
+		t.Errorf("View() = %q, want it to mention the current level", view)
+	}
+}
+
+func TestModelUpdateQuitsOnQ(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("Update() with 'q' should return a quit command")
+	}
+}
+
+func TestModelUpdateTracksWindowSize(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	resized := updated.(Model)
+	if w, h := resized.Size(); w != 80 || h != 24 {
+		t.Errorf("Size() = %d/%d, want 80/24", w, h)
+	}
+}
+
+func TestModelViewShowsResizePromptBelowMinimumSize(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	view := updated.(Model).View()
+
+	if !strings.Contains(view, "too small") {
+		t.Errorf("View() = %q, want a resize prompt for a 40x10 terminal", view)
+	}
+	if strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want the game view suppressed below the minimum size", view)
+	}
+}
+
+func TestModelViewRecoversAfterResizeAboveMinimumSize(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.WindowSizeMsg{Width: 40, Height: 10}))
+	m, _ = asModel(m.Update(tea.WindowSizeMsg{Width: 80, Height: 24}))
+
+	view := m.View()
+	if strings.Contains(view, "too small") {
+		t.Errorf("View() = %q, want the resize prompt cleared once the terminal is big enough", view)
+	}
+	if !strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want the game view back once resized", view)
+	}
+}
+
+func TestModelUpdateCyclesBuyModeOnB(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if !strings.Contains(m.View(), "Buy mode: x10") {
+		t.Fatalf("View() = %q, want it to start at buy mode x10", m.View())
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")}))
+	if !strings.Contains(m.View(), "Buy mode: x25") {
+		t.Errorf("View() = %q, want buy mode to advance to x25 after pressing b", m.View())
+	}
+}
+
+func TestModelUpdateTogglesAccessibilityModeOnA(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if m.currentStyles().Selected.GetReverse() {
+		t.Fatal("currentStyles().Selected.GetReverse() = true, want false before accessibility mode is toggled on")
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}))
+	if !m.currentStyles().Selected.GetReverse() {
+		t.Error("currentStyles().Selected.GetReverse() = false, want true once accessibility mode is toggled on")
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}))
+	if m.currentStyles().Selected.GetReverse() {
+		t.Error("currentStyles().Selected.GetReverse() = true, want false after toggling accessibility mode off again")
+	}
+}
+
+func TestModelUpdateTogglesHelpOverlayOnQuestionMark(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}))
+	view := m.View()
+	if !strings.Contains(view, "browse upgrades") {
+		t.Errorf("View() = %q, want the full help overlay listing every keybinding", view)
+	}
+	if strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want the game view replaced while the help overlay is open", view)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")}))
+	if !strings.Contains(m.View(), "monkey") {
+		t.Errorf("View() = %q, want the game view back once the help overlay is closed", m.View())
+	}
+}
+
+func TestModelWithHistoryRendersSparklineAndReschedulesTick(t *testing.T) {
+	history := func() []float64 { return []float64{1, 5, 10} }
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), history, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if cmd := m.Init(); cmd == nil {
+		t.Error("Init() = nil, want a tick command scheduled when a history func is configured")
+	}
+
+	if !strings.Contains(m.View(), "Progression:") {
+		t.Errorf("View() = %q, want a progression sparkline", m.View())
+	}
+
+	_, cmd := asModel(m.Update(historyTickMsg{}))
+	if cmd == nil {
+		t.Error("Update(historyTickMsg{}) returned a nil cmd, want the tick rescheduled")
+	}
+}
+
+func TestModelHistoryTickAdvancesAnimationAndShowsBurstOnIncrease(t *testing.T) {
+	history := func() []float64 { return []float64{1, 5, 10} }
+	state := &db.GameState{Keystrokes: 100}
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, state, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), history, nil, OfflineEarnings{}, nil, nil, nil)
+
+	state.Keystrokes = 112
+	m, _ = asModel(m.Update(historyTickMsg{}))
+
+	if !strings.Contains(m.View(), "+12!") {
+		t.Errorf("View() = %q, want a burst showing the 12 keystrokes gained since the last tick", m.View())
+	}
+
+	m, _ = asModel(m.Update(historyTickMsg{}))
+	if strings.Contains(m.View(), "+12!") {
+		t.Errorf("View() = %q, want the burst cleared once a tick passes with no further increase", m.View())
+	}
+}
+
+func TestModelLowBandwidthModeHidesAnimation(t *testing.T) {
+	history := func() []float64 { return []float64{1, 5, 10} }
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), history, nil, OfflineEarnings{}, nil, nil, nil)
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}))
+
+	if strings.Contains(m.View(), monkeyAnimationFrames[0]) {
+		t.Errorf("View() = %q, want the typing animation hidden in low-bandwidth mode", m.View())
+	}
+}
+
+func TestModelWithoutHistorySkipsTickAndSparkline(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if cmd := m.Init(); cmd != nil {
+		t.Error("Init() returned a cmd, want nil when no history func is configured")
+	}
+
+	if strings.Contains(m.View(), "Progression:") {
+		t.Errorf("View() = %q, want no progression line when no history func is configured", m.View())
+	}
+}
+
+func TestModelUpdateTogglesNotificationPanelOnN(t *testing.T) {
+	notifications := func() []*db.Notification {
+		return []*db.Notification{
+			{ID: 1, Message: "reached level 10", Severity: db.NotificationSuccess},
+			{ID: 2, Message: "careful now", Severity: db.NotificationWarning},
+		}
+	}
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, notifications, OfflineEarnings{}, nil, nil, nil)
+	m, _ = asModel(m.Update(toastTickMsg{}))
+
+	if !strings.Contains(m.View(), "reached level 10") {
+		t.Errorf("View() = %q, want the new notifications toasted inline", m.View())
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}))
+	view := m.View()
+	if !strings.Contains(view, "Notification history:") {
+		t.Errorf("View() = %q, want the notification panel open", view)
+	}
+	if strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want the game view replaced while the notification panel is open", view)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}))
+	if !strings.Contains(m.View(), "monkey") {
+		t.Errorf("View() = %q, want the game view back once the notification panel is closed", m.View())
+	}
+}
+
+func TestModelWithNotificationsSchedulesToastTick(t *testing.T) {
+	notifications := func() []*db.Notification { return nil }
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, notifications, OfflineEarnings{}, nil, nil, nil)
+
+	if cmd := m.Init(); cmd == nil {
+		t.Error("Init() = nil, want a tick command scheduled when a notifications func is configured")
+	}
+
+	_, cmd := asModel(m.Update(toastTickMsg{}))
+	if cmd == nil {
+		t.Error("Update(toastTickMsg{}) returned a nil cmd, want the tick rescheduled")
+	}
+}
+
+func TestModelWithoutNotificationsSkipsInlineSummary(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if strings.Contains(m.View(), "Notifications:") {
+		t.Errorf("View() = %q, want no notifications section when no notifications func is configured", m.View())
+	}
+}
+
+func TestModelUpdateTogglesStoryBrowserOnS(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{CurrentLevel: 10}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if !strings.Contains(m.View(), "Story: Chapter 3") {
+		t.Errorf("View() = %q, want the current chapter shown inline", m.View())
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}))
+	view := m.View()
+	if !strings.Contains(view, "Hello, World") {
+		t.Errorf("View() = %q, want the story browser open on the current chapter", view)
+	}
+	if strings.Contains(view, "Welcome back") {
+		t.Errorf("View() = %q, want the game view replaced while the story browser is open", view)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}))
+	if !strings.Contains(m.View(), "Welcome back") {
+		t.Errorf("View() = %q, want the game view back once the story browser is closed", m.View())
+	}
+}
+
+func TestModelShowsOfflineEarningsModalOnceThenDismisses(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{Away: time.Hour, Keystrokes: 500}, nil, nil, nil)
+
+	view := m.View()
+	if !strings.Contains(view, "While you were away") {
+		t.Errorf("View() = %q, want the offline earnings summary shown first", view)
+	}
+	if strings.Contains(view, "Welcome back") {
+		t.Errorf("View() = %q, want the game view suppressed until the summary is dismissed", view)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}))
+	if !strings.Contains(m.View(), "Welcome back") {
+		t.Errorf("View() = %q, want the game view back once the offline earnings summary is dismissed", m.View())
+	}
+}
+
+func TestModelWithoutOfflineEarningsSkipsModal(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if !strings.Contains(m.View(), "Welcome back") {
+		t.Errorf("View() = %q, want the game view shown immediately without offline earnings", m.View())
+	}
+}
+
+func TestModelViewStacksPanelsBelowWideLayoutWidth(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.WindowSizeMsg{Width: 80, Height: 24}))
+	view := m.View()
+
+	welcomeLine, upgradesLine := -1, -1
+	for i, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Welcome back") {
+			welcomeLine = i
+		}
+		if strings.Contains(line, "Upgrades:") {
+			upgradesLine = i
+		}
+	}
+	if welcomeLine < 0 || upgradesLine < 0 || upgradesLine <= welcomeLine {
+		t.Errorf("View() = %q, want the status summary stacked above the upgrade list", view)
+	}
+}
+
+func TestModelViewPlacesPanelsSideBySideAtWideLayoutWidth(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.WindowSizeMsg{Width: 120, Height: 24}))
+	view := m.View()
+
+	found := false
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Welcome back") && strings.Contains(line, "Upgrades:") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("View() = %q, want the status summary and upgrade list on the same line in a wide layout", view)
+	}
+}
+
+func TestModelViewTruncatesBannerToTerminalWidth(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, strings.Repeat("x", 200), ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.WindowSizeMsg{Width: 80, Height: 24}))
+	view := m.View()
+
+	for _, line := range strings.Split(view, "\n") {
+		if len([]rune(line)) > 80 {
+			t.Errorf("View() line %q is %d runes, want it truncated to the 80-column terminal", line, len([]rune(line)))
+		}
+	}
+}
+
+func TestModelUpdateSavesOnShiftS(t *testing.T) {
+	saved := 0
+	save := func() error { saved++; return nil }
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, save, nil)
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")}))
+	if saved != 1 {
+		t.Errorf("saved = %d, want 1 after pressing the save key", saved)
+	}
+}
+
+func TestModelUpdateSavesBeforeQuitting(t *testing.T) {
+	saved := 0
+	save := func() error { saved++; return nil }
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, save, nil)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if saved != 1 {
+		t.Errorf("saved = %d, want 1 before quitting", saved)
+	}
+	if cmd == nil {
+		t.Fatal("Update() with 'q' should still return a quit command")
+	}
+}
+
+func TestModelUpdateTogglesLeaderboardOnL(t *testing.T) {
+	leaderboard := func(offset, limit int) []*db.LeaderboardEntry {
+		return []*db.LeaderboardEntry{{PlayerID: "p1", Username: "monkey", Keystrokes: 100}}
+	}
+	m := NewModelWithAll(&db.Player{ID: "p1", Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, leaderboard, nil, nil)
+
+	m, cmd := asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}))
+	if cmd == nil {
+		t.Error("Update('L') returned a nil cmd, want a refresh tick scheduled")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Leaderboard:") {
+		t.Errorf("View() = %q, want the leaderboard panel open", view)
+	}
+	if !strings.Contains(view, "monkey") {
+		t.Errorf("View() = %q, want the fetched entry shown", view)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}))
+	if strings.Contains(m.View(), "Leaderboard:") {
+		t.Errorf("View() = %q, want the leaderboard panel closed", m.View())
+	}
+}
+
+func TestModelWithoutLeaderboardFuncSkipsPanel(t *testing.T) {
+	m := NewModelWithAll(&db.Player{ID: "p1", Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}))
+	if !strings.Contains(m.View(), "No entries") {
+		t.Errorf("View() = %q, want the panel's empty-page message when no leaderboard func is configured", m.View())
+	}
+}
+
+func TestModelLeaderboardTickStopsOnceClosed(t *testing.T) {
+	calls := 0
+	leaderboard := func(offset, limit int) []*db.LeaderboardEntry {
+		calls++
+		return nil
+	}
+	m := NewModelWithAll(&db.Player{ID: "p1", Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, leaderboard, nil, nil)
+
+	_, cmd := asModel(m.Update(leaderboardTickMsg{}))
+	if cmd != nil {
+		t.Error("Update(leaderboardTickMsg{}) returned a cmd while the panel is closed, want nil")
+	}
+	if calls != 0 {
+		t.Errorf("leaderboard func called %d times while the panel is closed, want 0", calls)
+	}
+}
+
+func TestModelLeaderboardPagingRefetches(t *testing.T) {
+	var lastOffset int
+	leaderboard := func(offset, limit int) []*db.LeaderboardEntry {
+		lastOffset = offset
+		return nil
+	}
+	m := NewModelWithAll(&db.Player{ID: "p1", Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, leaderboard, nil, nil)
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}))
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")}))
+	if lastOffset != leaderboardPageSize {
+		t.Errorf("lastOffset = %d, want %d after paging right", lastOffset, leaderboardPageSize)
+	}
+}
+
+func TestModelRoutesKeysToUpgradeFilterInsteadOfGlobalToggles(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}))
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}))
+
+	if m.showStory {
+		t.Error("showStory = true, want the 's' keystroke to go to the upgrade filter instead of toggling the story browser")
+	}
+	if !strings.Contains(m.upgrades.filter.Value(), "s") {
+		t.Errorf("upgrade filter value = %q, want it to have received the 's' keystroke", m.upgrades.filter.Value())
+	}
+}
+
+func TestModelUpdateTogglesLowBandwidthModeOnShiftB(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if m.currentStyles().Selected.GetReverse() {
+		t.Fatal("currentStyles().Selected.GetReverse() = true, want false before low-bandwidth mode is toggled on")
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}))
+	if !m.currentStyles().Selected.GetReverse() {
+		t.Error("currentStyles().Selected.GetReverse() = false, want true once low-bandwidth mode is toggled on")
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}))
+	if m.currentStyles().Selected.GetReverse() {
+		t.Error("currentStyles().Selected.GetReverse() = true, want false after toggling low-bandwidth mode off again")
+	}
+}
+
+func TestModelLowBandwidthModeStretchesTickInterval(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if got := m.tickInterval(historyTickInterval); got != historyTickInterval {
+		t.Errorf("tickInterval() = %v, want %v before low-bandwidth mode is toggled on", got, historyTickInterval)
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")}))
+	if want := historyTickInterval * lowBandwidthTickMultiplier; m.tickInterval(historyTickInterval) != want {
+		t.Errorf("tickInterval() = %v, want %v once low-bandwidth mode is toggled on", m.tickInterval(historyTickInterval), want)
+	}
+}
+
+func TestModelUpdateTogglesDashboardOnShiftD(t *testing.T) {
+	m := NewModelWithAll(&db.Player{Username: "monkey"}, &db.GameState{}, "", ThemeDefault, DefaultKeyMap(), NewCatalog(LocaleEN), nil, nil, OfflineEarnings{}, nil, nil, nil)
+
+	if m.showDashboard {
+		t.Fatal("showDashboard = true, want false before toggling")
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")}))
+	if !m.showDashboard {
+		t.Error("showDashboard = false, want true once toggled on")
+	}
+	if !strings.Contains(m.View(), "Dashboard:") {
+		t.Errorf("View() = %q, want it to show the dashboard heading", m.View())
+	}
+
+	m, _ = asModel(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")}))
+	if m.showDashboard {
+		t.Error("showDashboard = true, want false after toggling off again")
+	}
+}
+
+func asModel(m tea.Model, cmd tea.Cmd) (Model, tea.Cmd) {
+	return m.(Model), cmd
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// notificationPanelHeight is how many rows of notification history are
+// visible at once; a history longer than this scrolls instead of growing
+// the view.
+const notificationPanelHeight = 10
+
+// notificationPanelModel renders a player's full notification history
+// inside a scrolling viewport, oldest first, with severity coloring and a
+// read marker on every entry that hasn't been shown in the panel yet.
+type notificationPanelModel struct {
+	notifications []*db.Notification
+	read          map[int64]bool
+	viewport      viewport.Model
+	styles        Styles
+}
+
+// newNotificationPanelModel builds an empty notificationPanelModel,
+// rendering severity-colored rows with styles.
+func newNotificationPanelModel(styles Styles) notificationPanelModel {
+	return notificationPanelModel{
+		read:     make(map[int64]bool),
+		viewport: viewport.New(0, notificationPanelHeight),
+		styles:   styles,
+	}
+}
+
+// SetNotifications replaces the panel's history with notifications and
+// scrolls to the bottom to show the most recent entry, marking every one
+// of them read in the process: opening the panel is what it means to
+// have seen them.
+func (m *notificationPanelModel) SetNotifications(notifications []*db.Notification) {
+	m.notifications = notifications
+	m.viewport.SetContent(m.render())
+	m.viewport.GotoBottom()
+	for _, n := range notifications {
+		m.read[n.ID] = true
+	}
+}
+
+// Update scrolls the viewport.
+func (m notificationPanelModel) Update(msg tea.Msg) notificationPanelModel {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up", "k":
+			m.viewport.LineUp(1)
+		case "down", "j":
+			m.viewport.LineDown(1)
+		}
+	}
+	return m
+}
+
+// SetWidth resizes the viewport's rows to width.
+func (m *notificationPanelModel) SetWidth(width int) {
+	m.viewport.Width = width
+}
+
+// SetStyles switches the palette rows render with, e.g. when the player
+// toggles accessibility mode, and immediately re-renders the viewport's
+// content so the change is visible without waiting for the next poll.
+func (m *notificationPanelModel) SetStyles(styles Styles) {
+	m.styles = styles
+	m.viewport.SetContent(m.render())
+}
+
+// severityStyle returns the style a notification of severity should
+// render with, falling back to no styling for an unrecognized severity
+// rather than panicking.
+func severityStyle(severity string, styles Styles) lipgloss.Style {
+	switch severity {
+	case db.NotificationSuccess:
+		return styles.Accent
+	case db.NotificationWarning:
+		return styles.Warning
+	default:
+		return lipgloss.NewStyle()
+	}
+}
+
+// render draws every notification, oldest first, with an unread marker
+// on any entry not yet shown in the panel and its timestamp and message
+// colored by severity.
+func (m notificationPanelModel) render() string {
+	lines := make([]string, len(m.notifications))
+	for i, n := range m.notifications {
+		marker := " "
+		if !m.read[n.ID] {
+			marker = "*"
+		}
+		row := fmt.Sprintf("%s %s  %s", marker, n.CreatedAt.Local().Format("15:04:05"), n.Message)
+		lines[i] = severityStyle(n.Severity, m.styles).Render(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the viewport, with a "^"/"v" indicator above or below it
+// whenever rows are scrolled out of view in that direction.
+func (m notificationPanelModel) View() string {
+	if len(m.notifications) == 0 {
+		return "No notifications yet."
+	}
+
+	above := " "
+	if m.viewport.YOffset > 0 {
+		above = "^"
+	}
+	below := " "
+	if !m.viewport.AtBottom() {
+		below = "v"
+	}
+	return above + "\n" + m.viewport.View() + "\n" + below
+}
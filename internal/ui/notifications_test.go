@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestNotificationPanelSetNotificationsMarksThemRead(t *testing.T) {
+	m := newNotificationPanelModel(StylesFor(ThemeDefault))
+	m.SetNotifications([]*db.Notification{{ID: 1, Message: "reached level 10"}})
+
+	if !strings.Contains(m.render(), "reached level 10") {
+		t.Fatalf("render() = %q, want it to include the notification", m.render())
+	}
+	if strings.Contains(m.render(), "*") {
+		t.Errorf("render() = %q, want no unread marker once SetNotifications has shown it", m.render())
+	}
+}
+
+func TestNotificationPanelUnreadUntilShown(t *testing.T) {
+	m := newNotificationPanelModel(StylesFor(ThemeDefault))
+	m.notifications = []*db.Notification{{ID: 1, Message: "reached level 10"}}
+
+	if !strings.Contains(m.render(), "*") {
+		t.Errorf("render() = %q, want an unread marker before SetNotifications has shown it", m.render())
+	}
+}
+
+func TestNotificationPanelViewReportsEmptyHistory(t *testing.T) {
+	m := newNotificationPanelModel(StylesFor(ThemeDefault))
+
+	if got := m.View(); got != "No notifications yet." {
+		t.Errorf("View() = %q, want the empty-history message", got)
+	}
+}
@@ -0,0 +1,18 @@
+package ui
+
+import "time"
+
+// OfflineEarnings summarizes production credited for time a player spent
+// disconnected, so NewModelWithAll can show them a one-time summary
+// instead of their numbers just being different when they reconnect.
+// A zero OfflineEarnings means there was nothing to summarize.
+type OfflineEarnings struct {
+	Away       time.Duration
+	Keystrokes float64
+}
+
+// formatDuration renders d to the nearest second, e.g. "1h32m05s", for the
+// offline earnings summary.
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
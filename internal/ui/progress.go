@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// keystrokesPerWord, wordsPerProgram, and programsPerAI are the
+// thresholds a milestone progress bar fills toward: every 100 keystrokes
+// puts a player within reach of their next word, every 10 words their
+// next program, and every 5 programs their next AI automation.
+const (
+	keystrokesPerWord = 100
+	wordsPerProgram   = 10
+	programsPerAI     = 5
+)
+
+// progressBarWidth is how many characters wide a milestone bar renders,
+// filled and empty segments included.
+const progressBarWidth = 20
+
+// milestoneProgress reports how far state is toward its next word,
+// program, and AI automation, each as a fraction between 0 and 1 of the
+// resource below it accumulated since the last one was formed.
+func milestoneProgress(state *db.GameState) (word, program, ai float64) {
+	word = fraction(state.Keystrokes, keystrokesPerWord)
+	program = fraction(float64(state.Words), wordsPerProgram)
+	ai = fraction(float64(state.Programs), programsPerAI)
+	return word, program, ai
+}
+
+// fraction returns how far value is past its most recently crossed
+// multiple of per, as a fraction between 0 and 1.
+func fraction(value float64, per float64) float64 {
+	if per <= 0 {
+		return 0
+	}
+	remainder := math.Mod(value, per)
+	return remainder / per
+}
+
+// progressBar renders fraction, clamped to [0, 1], as a bar progressBarWidth
+// characters wide made of filled and empty blocks, so a milestone that's
+// mostly there reads at a glance rather than requiring the player to do
+// the division themselves.
+func progressBar(fraction float64) string {
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	filled := int(fraction*float64(progressBarWidth) + 0.5)
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled) + "]"
+}
+
+// renderMilestones draws the three milestone progress bars for state,
+// localized with catalog: next word, next program, and next AI
+// automation.
+func renderMilestones(state *db.GameState, catalog Catalog) string {
+	word, program, ai := milestoneProgress(state)
+	return strings.Join([]string{
+		catalog.T("progress.word", progressBar(word), word*100),
+		catalog.T("progress.program", progressBar(program), program*100),
+		catalog.T("progress.ai", progressBar(ai), ai*100),
+	}, "\n")
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestMilestoneProgressComputesFractionsFromEachThreshold(t *testing.T) {
+	state := &db.GameState{Keystrokes: 50, Words: 3, Programs: 2}
+	word, program, ai := milestoneProgress(state)
+
+	if word != 0.5 {
+		t.Errorf("word = %v, want 0.5", word)
+	}
+	if program != 0.3 {
+		t.Errorf("program = %v, want 0.3", program)
+	}
+	if ai != 0.4 {
+		t.Errorf("ai = %v, want 0.4", ai)
+	}
+}
+
+func TestMilestoneProgressWrapsAtEachThreshold(t *testing.T) {
+	state := &db.GameState{Keystrokes: 250}
+	word, _, _ := milestoneProgress(state)
+
+	if word != 0.5 {
+		t.Errorf("word = %v, want 0.5 for 250 keystrokes (2 full words plus half of the next)", word)
+	}
+}
+
+func TestProgressBarFillsProportionallyAndClamps(t *testing.T) {
+	if got := progressBar(0); strings.Count(got, "=") != 0 {
+		t.Errorf("progressBar(0) = %q, want no filled segments", got)
+	}
+	if got := progressBar(1); strings.Count(got, "=") != progressBarWidth {
+		t.Errorf("progressBar(1) = %q, want every segment filled", got)
+	}
+	if got := progressBar(2); strings.Count(got, "=") != progressBarWidth {
+		t.Errorf("progressBar(2) = %q, want fractions above 1 clamped to fully filled", got)
+	}
+	if got := progressBar(-1); strings.Count(got, "=") != 0 {
+		t.Errorf("progressBar(-1) = %q, want fractions below 0 clamped to empty", got)
+	}
+}
+
+func TestRenderMilestonesIncludesAllThreeBars(t *testing.T) {
+	got := renderMilestones(&db.GameState{Keystrokes: 50, Words: 3, Programs: 2}, NewCatalog(LocaleEN))
+
+	for _, want := range []string{"Next word:", "Next program:", "Next AI:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderMilestones() = %q, want it to include %q", got, want)
+		}
+	}
+}
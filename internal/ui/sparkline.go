@@ -0,0 +1,40 @@
+package ui
+
+import "strings"
+
+// sparkBlocks are the block characters Sparkline scales a series across,
+// lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled
+// between their minimum and maximum, the game view's at-a-glance history
+// graph for metrics like keystrokes-per-second over the last few
+// minutes. An empty series renders as an empty string; a series with no
+// variation renders as a flat line at the lowest block.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	spread := max - min
+	for _, v := range values {
+		if spread == 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		idx := int((v - min) / spread * float64(len(sparkBlocks)-1))
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
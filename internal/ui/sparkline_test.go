@@ -0,0 +1,30 @@
+package ui
+
+import "testing"
+
+func TestSparklineEmptySeriesRendersEmptyString(t *testing.T) {
+	if got := Sparkline(nil); got != "" {
+		t.Errorf("Sparkline(nil) = %q, want empty string", got)
+	}
+}
+
+func TestSparklineFlatSeriesRendersLowestBlockThroughout(t *testing.T) {
+	got := Sparkline([]float64{5, 5, 5})
+	want := string(sparkBlocks[0]) + string(sparkBlocks[0]) + string(sparkBlocks[0])
+	if got != want {
+		t.Errorf("Sparkline(flat) = %q, want %q", got, want)
+	}
+}
+
+func TestSparklineScalesLowAndHighToEndBlocks(t *testing.T) {
+	got := []rune(Sparkline([]float64{0, 10}))
+	if len(got) != 2 {
+		t.Fatalf("Sparkline() = %q, want 2 runes", string(got))
+	}
+	if got[0] != sparkBlocks[0] {
+		t.Errorf("first rune = %q, want the lowest block %q", got[0], sparkBlocks[0])
+	}
+	if got[1] != sparkBlocks[len(sparkBlocks)-1] {
+		t.Errorf("last rune = %q, want the highest block %q", got[1], sparkBlocks[len(sparkBlocks)-1])
+	}
+}
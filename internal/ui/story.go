@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// storyPanelHeight is how many rows of a chapter's content are visible at
+// once inside the story browser; a chapter longer than this scrolls
+// instead of growing the view.
+const storyPanelHeight = 10
+
+// storyBrowserModel lets a player page through every story chapter
+// they've unlocked so far, word-wrapping each one's content to the
+// terminal's width inside a scrolling viewport.
+type storyBrowserModel struct {
+	chapters []game.Chapter
+	selected int
+	viewport viewport.Model
+	styles   Styles
+}
+
+// newStoryBrowserModel builds an empty storyBrowserModel, rendering
+// content with styles.
+func newStoryBrowserModel(styles Styles) storyBrowserModel {
+	return storyBrowserModel{
+		viewport: viewport.New(0, storyPanelHeight),
+		styles:   styles,
+	}
+}
+
+// SetChapters replaces the browser's chapter list with the player's
+// currently unlocked chapters, opening on the most recently unlocked one
+// so reopening the browser picks up where the story left off.
+func (m *storyBrowserModel) SetChapters(chapters []game.Chapter) {
+	m.chapters = chapters
+	m.selected = len(chapters) - 1
+	m.viewport.SetContent(m.render())
+	m.viewport.GotoTop()
+}
+
+// Update handles paging between chapters and scrolling the current
+// chapter's content.
+func (m storyBrowserModel) Update(msg tea.Msg) storyBrowserModel {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "left", "h":
+			if m.selected > 0 {
+				m.selected--
+				m.viewport.SetContent(m.render())
+				m.viewport.GotoTop()
+			}
+		case "right", "l":
+			if m.selected < len(m.chapters)-1 {
+				m.selected++
+				m.viewport.SetContent(m.render())
+				m.viewport.GotoTop()
+			}
+		case "up", "k":
+			m.viewport.LineUp(1)
+		case "down", "j":
+			m.viewport.LineDown(1)
+		}
+	}
+	return m
+}
+
+// SetWidth resizes the viewport's rows, and rewraps the current
+// chapter's content, to width.
+func (m *storyBrowserModel) SetWidth(width int) {
+	m.viewport.Width = width
+	m.viewport.SetContent(m.render())
+}
+
+// SetStyles switches the palette the browser renders with, e.g. when the
+// player toggles accessibility mode, and immediately re-renders the
+// viewport's content so the change is visible without waiting for the
+// next page.
+func (m *storyBrowserModel) SetStyles(styles Styles) {
+	m.styles = styles
+	m.viewport.SetContent(m.render())
+}
+
+// render draws the selected chapter's title and word-wrapped content.
+func (m storyBrowserModel) render() string {
+	if len(m.chapters) == 0 {
+		return ""
+	}
+
+	c := m.chapters[m.selected]
+	title := m.styles.Accent.Render(fmt.Sprintf("Chapter %d: %s", c.ID, c.Title))
+
+	width := m.viewport.Width
+	if width <= 0 {
+		return title + "\n\n" + c.Content
+	}
+	return title + "\n\n" + wordWrap(c.Content, width)
+}
+
+// wordWrap breaks text into lines no wider than width, breaking only at
+// spaces so no word is split mid-character.
+func wordWrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line == "" {
+			line = word
+			continue
+		}
+		if len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line += " " + word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the viewport, with a "^"/"v" indicator above or below it
+// whenever rows are scrolled out of view in that direction, and a
+// "chapter X/N" position indicator for paging between chapters.
+func (m storyBrowserModel) View() string {
+	if len(m.chapters) == 0 {
+		return "No chapters unlocked yet."
+	}
+
+	above := " "
+	if m.viewport.YOffset > 0 {
+		above = "^"
+	}
+	below := " "
+	if !m.viewport.AtBottom() {
+		below = "v"
+	}
+
+	position := fmt.Sprintf("Chapter %d/%d (left/right to page)", m.selected+1, len(m.chapters))
+	return position + "\n" + above + "\n" + m.viewport.View() + "\n" + below
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+func TestStoryBrowserOpensOnMostRecentlyUnlockedChapter(t *testing.T) {
+	m := newStoryBrowserModel(StylesFor(ThemeDefault))
+	m.SetChapters(game.UnlockedChapters(20))
+
+	if !strings.Contains(m.render(), "Writing Programs") {
+		t.Errorf("render() = %q, want the most recently unlocked chapter shown", m.render())
+	}
+}
+
+func TestStoryBrowserPagesBetweenChapters(t *testing.T) {
+	m := newStoryBrowserModel(StylesFor(ThemeDefault))
+	m.SetChapters(game.UnlockedChapters(20))
+
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if !strings.Contains(m.render(), "Hello, World") {
+		t.Errorf("render() = %q, want the previous chapter after paging left", m.render())
+	}
+
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if !strings.Contains(m.render(), "Writing Programs") {
+		t.Errorf("render() = %q, want to page back to the later chapter", m.render())
+	}
+}
+
+func TestStoryBrowserPagingStopsAtBounds(t *testing.T) {
+	m := newStoryBrowserModel(StylesFor(ThemeDefault))
+	m.SetChapters(game.UnlockedChapters(1))
+
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if m.selected != 0 {
+		t.Errorf("selected = %d, want 0 (paging past the only chapter should have no effect)", m.selected)
+	}
+}
+
+func TestStoryBrowserViewReportsNoChaptersUnlocked(t *testing.T) {
+	m := newStoryBrowserModel(StylesFor(ThemeDefault))
+
+	if got := m.View(); got != "No chapters unlocked yet." {
+		t.Errorf("View() = %q, want the empty-history message", got)
+	}
+}
+
+func TestWordWrapBreaksOnlyAtSpaces(t *testing.T) {
+	got := wordWrap("the quick brown fox jumps", 10)
+	for _, line := range strings.Split(got, "\n") {
+		if len(line) > 10 {
+			t.Errorf("wordWrap() line %q is longer than width 10", line)
+		}
+	}
+	if strings.Join(strings.Fields(got), " ") != "the quick brown fox jumps" {
+		t.Errorf("wordWrap() = %q, want it to preserve every word", got)
+	}
+}
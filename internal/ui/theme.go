@@ -0,0 +1,68 @@
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+// Theme names a color palette View renders with. It's a string rather
+// than an int so it round-trips cleanly through config files without a
+// lookup table.
+type Theme string
+
+const (
+	ThemeDefault    Theme = "default"
+	ThemeSolarized  Theme = "solarized"
+	ThemeMonochrome Theme = "monochrome"
+)
+
+// Styles holds the lipgloss styles a Theme renders with, so a view only
+// ever refers to semantic style names (Banner, Selected, Accent) instead
+// of hardcoding colors of its own.
+type Styles struct {
+	Banner   lipgloss.Style
+	Selected lipgloss.Style
+	Accent   lipgloss.Style
+	Warning  lipgloss.Style
+}
+
+// AccessibleStyles returns a high-contrast, color-free palette that
+// overrides whatever Theme is selected: every element renders in plain
+// bold (or reverse video for the selected row) with no color, for
+// terminals and fonts that render subtle colors poorly and for
+// colorblind players. No view in this package uses emoji, so accessible
+// mode has nothing to strip there.
+func AccessibleStyles() Styles {
+	return Styles{
+		Banner:   lipgloss.NewStyle().Bold(true),
+		Selected: lipgloss.NewStyle().Bold(true).Reverse(true),
+		Accent:   lipgloss.NewStyle().Bold(true),
+		Warning:  lipgloss.NewStyle().Bold(true).Reverse(true),
+	}
+}
+
+// StylesFor returns the Styles for theme, falling back to ThemeDefault
+// for an unrecognized name so a bad config value degrades to the
+// default palette instead of panicking.
+func StylesFor(theme Theme) Styles {
+	switch theme {
+	case ThemeSolarized:
+		return Styles{
+			Banner:   lipgloss.NewStyle().Foreground(lipgloss.Color("#268bd2")).Bold(true),
+			Selected: lipgloss.NewStyle().Foreground(lipgloss.Color("#859900")).Bold(true),
+			Accent:   lipgloss.NewStyle().Foreground(lipgloss.Color("#b58900")),
+			Warning:  lipgloss.NewStyle().Foreground(lipgloss.Color("#dc322f")).Bold(true),
+		}
+	case ThemeMonochrome:
+		return Styles{
+			Banner:   lipgloss.NewStyle().Bold(true),
+			Selected: lipgloss.NewStyle().Bold(true).Underline(true),
+			Accent:   lipgloss.NewStyle(),
+			Warning:  lipgloss.NewStyle().Bold(true).Underline(true),
+		}
+	default:
+		return Styles{
+			Banner:   lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Bold(true),
+			Selected: lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true),
+			Accent:   lipgloss.NewStyle().Foreground(lipgloss.Color("14")),
+			Warning:  lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true),
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package ui
+
+import "testing"
+
+func TestStylesForKnownThemesApplyBold(t *testing.T) {
+	themes := []Theme{ThemeDefault, ThemeSolarized, ThemeMonochrome}
+	for _, theme := range themes {
+		if !StylesFor(theme).Banner.GetBold() {
+			t.Errorf("StylesFor(%q).Banner.GetBold() = false, want true", theme)
+		}
+	}
+}
+
+func TestStylesForUnknownThemeFallsBackToDefault(t *testing.T) {
+	got := StylesFor("not-a-real-theme").Accent.GetForeground()
+	want := StylesFor(ThemeDefault).Accent.GetForeground()
+	if got != want {
+		t.Errorf("StylesFor(%q).Accent.GetForeground() = %v, want the default theme's %v", "not-a-real-theme", got, want)
+	}
+}
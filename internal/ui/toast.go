@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+// toastLifetime is how long a toast stays in the tray before it's
+// dropped, so a burst of events doesn't linger on screen forever.
+const toastLifetime = 5 * time.Second
+
+// maxToasts caps how many toasts are shown stacked at once, so a burst
+// of notifications doesn't fill the whole view.
+const maxToasts = 5
+
+// toast is one notification surfaced briefly in the game view rather
+// than sitting there permanently.
+type toast struct {
+	notification *db.Notification
+	expiresAt    time.Time
+}
+
+// toastTrayModel tracks which notifications have already been toasted
+// and which toasts are still live, so polling the same notifications
+// again doesn't toast them a second time.
+type toastTrayModel struct {
+	seen   map[int64]bool
+	toasts []toast
+}
+
+// newToastTrayModel builds an empty toastTrayModel.
+func newToastTrayModel() toastTrayModel {
+	return toastTrayModel{seen: make(map[int64]bool)}
+}
+
+// Notice adds a toast, expiring toastLifetime after now, for every
+// notification not already seen, then drops the oldest toasts beyond
+// maxToasts.
+func (m *toastTrayModel) Notice(notifications []*db.Notification, now time.Time) {
+	for _, n := range notifications {
+		if m.seen[n.ID] {
+			continue
+		}
+		m.seen[n.ID] = true
+		m.toasts = append(m.toasts, toast{notification: n, expiresAt: now.Add(toastLifetime)})
+	}
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+}
+
+// Expire drops every toast whose lifetime has elapsed as of now.
+func (m *toastTrayModel) Expire(now time.Time) {
+	live := m.toasts[:0]
+	for _, t := range m.toasts {
+		if now.Before(t.expiresAt) {
+			live = append(live, t)
+		}
+	}
+	m.toasts = live
+}
+
+// View renders the still-live toasts, oldest first and severity-colored,
+// or an empty string when there are none to show.
+func (m toastTrayModel) View(styles Styles) string {
+	lines := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		lines[i] = severityStyle(t.notification.Severity, styles).Render(t.notification.Message)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maker2413/TermIdle/internal/db"
+)
+
+func TestToastTrayNoticeSkipsAlreadySeenNotifications(t *testing.T) {
+	tray := newToastTrayModel()
+	now := time.Now()
+	n := &db.Notification{ID: 1, Message: "reached level 10"}
+
+	tray.Notice([]*db.Notification{n}, now)
+	tray.Notice([]*db.Notification{n}, now)
+
+	if len(tray.toasts) != 1 {
+		t.Fatalf("len(toasts) = %d, want 1 after noticing the same notification twice", len(tray.toasts))
+	}
+}
+
+func TestToastTrayNoticeCapsAtMaxToasts(t *testing.T) {
+	tray := newToastTrayModel()
+	now := time.Now()
+
+	var notifications []*db.Notification
+	for i := 0; i < maxToasts+3; i++ {
+		notifications = append(notifications, &db.Notification{ID: int64(i), Message: "event"})
+	}
+	tray.Notice(notifications, now)
+
+	if len(tray.toasts) != maxToasts {
+		t.Fatalf("len(toasts) = %d, want %d", len(tray.toasts), maxToasts)
+	}
+	if tray.toasts[0].notification.ID != 3 {
+		t.Errorf("toasts[0].notification.ID = %d, want the oldest toasts trimmed off", tray.toasts[0].notification.ID)
+	}
+}
+
+func TestToastTrayExpireDropsStaleToasts(t *testing.T) {
+	tray := newToastTrayModel()
+	now := time.Now()
+	tray.Notice([]*db.Notification{{ID: 1, Message: "old"}}, now.Add(-toastLifetime))
+	tray.Notice([]*db.Notification{{ID: 2, Message: "fresh"}}, now)
+
+	tray.Expire(now)
+
+	if len(tray.toasts) != 1 || tray.toasts[0].notification.ID != 2 {
+		t.Fatalf("toasts after Expire = %+v, want only the still-live toast", tray.toasts)
+	}
+}
+
+func TestToastTrayViewRendersLiveToasts(t *testing.T) {
+	tray := newToastTrayModel()
+	tray.Notice([]*db.Notification{{ID: 1, Message: "reached level 10", Severity: db.NotificationSuccess}}, time.Now())
+
+	view := tray.View(StylesFor(ThemeDefault))
+	if !strings.Contains(view, "reached level 10") {
+		t.Errorf("View() = %q, want the live toast's message", view)
+	}
+}
+
+func TestToastTrayViewReportsNoToasts(t *testing.T) {
+	tray := newToastTrayModel()
+
+	if got := tray.View(StylesFor(ThemeDefault)); got != "" {
+		t.Errorf("View() = %q, want an empty string with no live toasts", got)
+	}
+}
@@ -0,0 +1,302 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+// upgradeListHeight is how many rows of the upgrade list are visible at
+// once; a catalog longer than this scrolls instead of growing the view.
+const upgradeListHeight = 6
+
+// upgradeCategoryOrder is the order category headers appear in the
+// upgrade list, regardless of how the catalog itself is ordered.
+var upgradeCategoryOrder = []game.UpgradeCategory{
+	game.UpgradeCategoryProduction,
+	game.UpgradeCategoryAutomation,
+	game.UpgradeCategoryStory,
+}
+
+// upgradeCategoryMessageKeys maps each category to the Catalog key for
+// the section header shown above its upgrades.
+var upgradeCategoryMessageKeys = map[game.UpgradeCategory]string{
+	game.UpgradeCategoryProduction: "upgrades.category.production",
+	game.UpgradeCategoryAutomation: "upgrades.category.automation",
+	game.UpgradeCategoryStory:      "upgrades.category.story",
+}
+
+// upgradeListModel renders the upgrade catalog, grouped by category and
+// optionally narrowed by a name filter, inside a scrolling viewport with
+// indicators for rows scrolled out of view, keeping the selected row on
+// screen as the player moves through a list too long to fit a small
+// terminal.
+type upgradeListModel struct {
+	upgrades  []game.UpgradeDefinition
+	levels    map[string]int
+	selected  int
+	viewport  viewport.Model
+	styles    Styles
+	catalog   Catalog
+	filter    textinput.Model
+	filtering bool
+}
+
+// newUpgradeListModel builds an upgradeListModel over the full upgrade
+// catalog, rendering the selected row with styles.Selected and its text
+// with catalog. levels are the levels the player has already purchased,
+// keyed by upgrade type; an absent entry is treated as level 0.
+func newUpgradeListModel(styles Styles, catalog Catalog, levels map[string]int) upgradeListModel {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.CharLimit = 32
+	if levels == nil {
+		levels = make(map[string]int)
+	}
+	m := upgradeListModel{
+		upgrades: game.Upgrades(),
+		levels:   levels,
+		viewport: viewport.New(0, upgradeListHeight),
+		styles:   styles,
+		catalog:  catalog,
+		filter:   filter,
+	}
+	m.viewport.SetContent(m.render())
+	return m
+}
+
+// Filtering reports whether the upgrade list's search field is focused,
+// so key presses can be routed to it instead of the global keybindings.
+func (m upgradeListModel) Filtering() bool {
+	return m.filtering
+}
+
+// Update handles selection keys, paging keys for the search field, and
+// scrolls the viewport to keep the newly selected row visible.
+func (m upgradeListModel) Update(msg tea.Msg) upgradeListModel {
+	if m.filtering {
+		return m.updateFiltering(msg)
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "/":
+			m.filtering = true
+			m.filter.Focus()
+			return m
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "down", "j":
+			if m.selected < len(m.filtered())-1 {
+				m.selected++
+			}
+		}
+	}
+	m.viewport.SetContent(m.render())
+	m.scrollToSelected()
+	return m
+}
+
+// updateFiltering routes keys to the search field while it's focused,
+// exiting on Escape (clearing the filter) or Enter (keeping it).
+func (m upgradeListModel) updateFiltering(msg tea.Msg) upgradeListModel {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter.Blur()
+			m.filter.SetValue("")
+			m.selected = 0
+			m.viewport.SetContent(m.render())
+			m.scrollToSelected()
+			return m
+		case tea.KeyEnter:
+			m.filtering = false
+			m.filter.Blur()
+			return m
+		}
+	}
+	m.filter, _ = m.filter.Update(msg)
+	m.selected = 0
+	m.viewport.SetContent(m.render())
+	m.scrollToSelected()
+	return m
+}
+
+// filtered returns the upgrades whose name matches the search field,
+// case-insensitively, or the full catalog when it's empty.
+func (m upgradeListModel) filtered() []game.UpgradeDefinition {
+	query := strings.ToLower(m.filter.Value())
+	if query == "" {
+		return m.upgrades
+	}
+	var out []game.UpgradeDefinition
+	for _, u := range m.upgrades {
+		if strings.Contains(strings.ToLower(u.Name), query) {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// SetWidth resizes the viewport's rows to width.
+func (m *upgradeListModel) SetWidth(width int) {
+	m.viewport.Width = width
+}
+
+// SetStyles switches the palette rows render with, e.g. when the player
+// toggles accessibility mode, and immediately re-renders the viewport's
+// content so the change is visible without waiting for the next
+// navigation key.
+func (m *upgradeListModel) SetStyles(styles Styles) {
+	m.styles = styles
+	m.viewport.SetContent(m.render())
+}
+
+// scrollToSelected moves the viewport just far enough that the selected
+// row stays on screen, without otherwise disturbing the scroll position.
+func (m *upgradeListModel) scrollToSelected() {
+	_, line := m.renderLines()
+	if line < m.viewport.YOffset {
+		m.viewport.YOffset = line
+	}
+	if line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.YOffset = line - m.viewport.Height + 1
+	}
+}
+
+// renderLines draws every upgrade row under a header for its category,
+// marking the selected one, and reports which line of the result holds
+// it, for scrollToSelected to keep on screen.
+func (m upgradeListModel) renderLines() (lines []string, selectedLine int) {
+	filtered := m.filtered()
+	if len(filtered) == 0 {
+		return []string{m.catalog.T("upgrades.no_match")}, 0
+	}
+
+	grouped := make(map[game.UpgradeCategory][]game.UpgradeDefinition)
+	for _, u := range filtered {
+		grouped[u.Category] = append(grouped[u.Category], u)
+	}
+
+	i := 0
+	for _, category := range upgradeCategoryOrder {
+		upgrades := grouped[category]
+		if len(upgrades) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.Accent.Render(m.catalog.T(upgradeCategoryMessageKeys[category])))
+		for _, u := range upgrades {
+			row := fmt.Sprintf("  %-24s %10s", u.Name, FormatNumber(game.UpgradeCost(u.BaseCost, m.levels[u.Type]), false))
+			if i == m.selected {
+				row = m.styles.Selected.Render("> " + strings.TrimPrefix(row, "  "))
+				selectedLine = len(lines)
+			}
+			lines = append(lines, row)
+			i++
+		}
+	}
+	return lines, selectedLine
+}
+
+// render draws the upgrade list as a single string, for the viewport's
+// content.
+func (m upgradeListModel) render() string {
+	lines, _ := m.renderLines()
+	return strings.Join(lines, "\n")
+}
+
+// selectedUpgrade returns the upgrade the player currently has
+// highlighted, or ok=false if the filtered list is empty and nothing is
+// selected.
+func (m upgradeListModel) selectedUpgrade() (u game.UpgradeDefinition, ok bool) {
+	filtered := m.filtered()
+	if m.selected < 0 || m.selected >= len(filtered) {
+		return game.UpgradeDefinition{}, false
+	}
+	return filtered[m.selected], true
+}
+
+// renderDetail draws a pane for the selected upgrade showing its current
+// vs next effect on production, how long its next level takes to pay
+// back at that effect, and how much has been spent on it so far, to help
+// a player judge whether a purchase is worth it.
+func (m upgradeListModel) renderDetail() string {
+	u, ok := m.selectedUpgrade()
+	if !ok {
+		return ""
+	}
+
+	level := m.levels[u.Type]
+	cost := game.UpgradeCost(u.BaseCost, level)
+	currentEffect := float64(level) * game.UpgradeEffect(u.BaseCost)
+	nextEffect := float64(level+1) * game.UpgradeEffect(u.BaseCost)
+	totalSpent := game.CumulativeUpgradeCost(u.BaseCost, 0, level)
+	payback := formatDuration(time.Duration(cost / game.UpgradeEffect(u.BaseCost) * float64(time.Second)))
+
+	return strings.Join([]string{
+		m.styles.Accent.Render(u.Name),
+		"  " + m.catalog.T("upgrades.detail.current_effect", FormatNumber(currentEffect, false)),
+		"  " + m.catalog.T("upgrades.detail.next_effect", FormatNumber(nextEffect, false)),
+		"  " + m.catalog.T("upgrades.detail.next_level", FormatNumber(cost, false)),
+		"  " + m.catalog.T("upgrades.detail.payback", payback),
+		"  " + m.catalog.T("upgrades.detail.total_spent", FormatNumber(totalSpent, false)),
+	}, "\n")
+}
+
+// Purchase buys as many levels of the selected upgrade as mode affords
+// within budget, updating the returned model's owned level for it. ok is
+// false, and the returned model unchanged, if nothing is selected or budget
+// can't afford even a single level; otherwise upgradeType and newLevel
+// identify what was bought and cost is what the caller should deduct from
+// budget.
+func (m upgradeListModel) Purchase(mode game.BuyMode, budget float64) (updated upgradeListModel, upgradeType string, newLevel int, cost float64, ok bool) {
+	u, selOk := m.selectedUpgrade()
+	if !selOk {
+		return m, "", 0, 0, false
+	}
+
+	newLevel, cost, ok = game.NewUpgradeManager().Purchase(u.BaseCost, m.levels[u.Type], mode, budget)
+	if !ok {
+		return m, "", 0, 0, false
+	}
+
+	m.levels = maps.Clone(m.levels)
+	m.levels[u.Type] = newLevel
+	m.viewport.SetContent(m.render())
+	return m, u.Type, newLevel, cost, true
+}
+
+// View renders the search field when it's focused or has a value, the
+// viewport, and a "^"/"v" indicator above or below it whenever rows are
+// scrolled out of view in that direction.
+func (m upgradeListModel) View() string {
+	above := " "
+	if m.viewport.YOffset > 0 {
+		above = "^"
+	}
+	below := " "
+	if !m.viewport.AtBottom() {
+		below = "v"
+	}
+	list := above + "\n" + m.viewport.View() + "\n" + below
+	if m.filtering || m.filter.Value() != "" {
+		list = m.filter.View() + "\n" + list
+	}
+	if detail := m.renderDetail(); detail != "" {
+		list += "\n" + detail
+	}
+	return list
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/maker2413/TermIdle/internal/game"
+)
+
+func TestUpgradeListModelMarksFirstRowSelectedByDefault(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	lines := strings.Split(m.render(), "\n")
+	if !strings.HasPrefix(lines[0], "Production") {
+		t.Fatalf("render() first line = %q, want the first category header", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "> ") {
+		t.Errorf("render() second line = %q, want the first upgrade marked as selected", lines[1])
+	}
+}
+
+func TestUpgradeListModelPurchaseBuysSelectedUpgrade(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+
+	updated, upgradeType, level, cost, ok := m.Purchase(game.BuyModeX10, 1000)
+	if !ok {
+		t.Fatalf("Purchase() ok = false, want a 1000-keystroke budget to afford 10 levels of faster_typing")
+	}
+	if upgradeType != "faster_typing" {
+		t.Errorf("upgradeType = %q, want faster_typing (the first upgrade, selected by default)", upgradeType)
+	}
+	if level != 10 {
+		t.Errorf("level = %d, want 10 for a x10 purchase starting at level 0", level)
+	}
+	if cost <= 0 {
+		t.Errorf("cost = %v, want a positive cost", cost)
+	}
+	if updated.levels["faster_typing"] != 10 {
+		t.Errorf("levels[faster_typing] = %d, want 10 after Purchase()", updated.levels["faster_typing"])
+	}
+	if m.levels["faster_typing"] != 0 {
+		t.Errorf("original model's levels[faster_typing] = %d, want 0; Purchase() must not mutate the receiver", m.levels["faster_typing"])
+	}
+}
+
+func TestUpgradeListModelPurchaseFailsWithoutBudget(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+
+	_, _, _, _, ok := m.Purchase(game.BuyModeX10, 0)
+	if ok {
+		t.Error("Purchase() ok = true, want false with no budget to buy even one level")
+	}
+}
+
+func TestUpgradeListModelMovesSelectionDown(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	lines := strings.Split(m.render(), "\n")
+	if !strings.HasPrefix(lines[2], "> ") {
+		t.Errorf("render() third line = %q, want it marked as selected after moving down", lines[2])
+	}
+}
+
+func TestUpgradeListModelSelectionStopsAtBounds(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if m.selected != 0 {
+		t.Errorf("selected = %d, want 0 (moving up from the top should have no effect)", m.selected)
+	}
+
+	for range m.upgrades {
+		m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if m.selected != len(m.upgrades)-1 {
+		t.Errorf("selected = %d, want %d (moving down past the bottom should have no effect)", m.selected, len(m.upgrades)-1)
+	}
+}
+
+func TestUpgradeListModelShowsScrollIndicatorWhenBelowView(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	for range m.upgrades {
+		m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if !strings.Contains(m.View(), "^") {
+		t.Errorf("View() = %q, want a scroll-up indicator once the selection moves below the first page", m.View())
+	}
+}
+
+func TestUpgradeListModelGroupsUpgradesByCategory(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	got := m.render()
+
+	if !strings.Contains(got, "Production") || !strings.Contains(got, "Automation") {
+		t.Errorf("render() = %q, want both Production and Automation headers", got)
+	}
+	if strings.Index(got, "Production") > strings.Index(got, "Automation") {
+		t.Errorf("render() = %q, want Production listed before Automation", got)
+	}
+}
+
+func TestUpgradeListModelSlashOpensFilterField(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+
+	if m.Filtering() {
+		t.Fatal("Filtering() = true before pressing /")
+	}
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.Filtering() {
+		t.Fatal("Filtering() = false after pressing /, want true")
+	}
+}
+
+func TestUpgradeListModelFilterNarrowsResults(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "linter" {
+		m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	got := m.render()
+	if !strings.Contains(got, "Linter") {
+		t.Errorf("render() = %q, want the matching upgrade", got)
+	}
+	if strings.Contains(got, "Faster Typing") {
+		t.Errorf("render() = %q, want non-matching upgrades filtered out", got)
+	}
+}
+
+func TestUpgradeListModelFilterEscapeClearsAndCloses(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("linter")})
+	m = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if m.Filtering() {
+		t.Error("Filtering() = true after Escape, want false")
+	}
+	if !strings.Contains(m.render(), "Faster Typing") {
+		t.Errorf("render() = %q, want the full catalog back after clearing the filter", m.render())
+	}
+}
+
+func TestUpgradeListModelViewShowsDetailForSelectedUpgrade(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+
+	got := m.View()
+	if !strings.Contains(got, "Faster Typing") {
+		t.Errorf("View() = %q, want the first upgrade's detail pane", got)
+	}
+	if !strings.Contains(got, "Payback time:") {
+		t.Errorf("View() = %q, want a payback time line", got)
+	}
+}
+
+func TestUpgradeListModelDetailFollowsSelection(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+
+	got := m.View()
+	if !strings.Contains(got, "Auto Complete") {
+		t.Errorf("View() = %q, want the second upgrade's detail pane after moving down", got)
+	}
+}
+
+func TestUpgradeListModelFilterWithNoMatchesShowsMessage(t *testing.T) {
+	m := newUpgradeListModel(StylesFor(ThemeDefault), NewCatalog(LocaleEN), nil)
+	m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "nonexistent" {
+		m = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	if got := m.render(); got != "No upgrades match." {
+		t.Errorf("render() = %q, want the no-matches message", got)
+	}
+}
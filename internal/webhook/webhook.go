@@ -0,0 +1,155 @@
+// Package webhook delivers outgoing notifications about game milestones
+// (level-ups, new leaderboard leaders, ...) to external services such as
+// Discord bridges.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Event types the rest of the application can dispatch.
+const (
+	EventPlayerLevelUp        = "player.level_up"
+	EventNewLeaderboardLeader = "leaderboard.new_leader"
+)
+
+// Event is the JSON body POSTed to every configured URL.
+type Event struct {
+	Type      string         `json:"type"`
+	Data      map[string]any `json:"data"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// Config controls where webhooks are sent and how delivery is signed and
+// retried.
+type Config struct {
+	// URLs receive a POST of every event. A nil/empty Notifier is a no-op,
+	// so callers can leave this unset when webhooks aren't configured.
+	URLs []string
+	// Secret signs each request body with HMAC-SHA256, hex-encoded into the
+	// X-TermIdle-Signature header, so receivers can verify the payload came
+	// from this server. Leave empty to skip signing.
+	Secret string
+	// MaxAttempts is how many times delivery to a single URL is attempted
+	// before giving up. Defaults to 3.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry; each subsequent
+	// retry doubles it. Defaults to 500ms.
+	BaseBackoff time.Duration
+	// Timeout bounds a single delivery attempt. Defaults to 5s.
+	Timeout time.Duration
+}
+
+// DefaultConfig returns sane retry/timeout defaults with no URLs configured.
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: 3,
+		BaseBackoff: 500 * time.Millisecond,
+		Timeout:     5 * time.Second,
+	}
+}
+
+// Notifier delivers Events to a Config's URLs. The zero value is not
+// usable; use NewNotifier.
+type Notifier struct {
+	config Config
+	client *http.Client
+}
+
+// NewNotifier builds a Notifier from config, filling in any zero-valued
+// tuning fields with DefaultConfig's.
+func NewNotifier(config Config) *Notifier {
+	defaults := DefaultConfig()
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaults.BaseBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+
+	return &Notifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Notify delivers event to every configured URL in the background. Delivery
+// is best-effort: failures are logged rather than returned, since a down
+// webhook receiver should never block gameplay.
+func (n *Notifier) Notify(event Event) {
+	if n == nil || len(n.config.URLs) == 0 {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("webhook: failed to encode event %s: %v", event.Type, err)
+		return
+	}
+
+	for _, url := range n.config.URLs {
+		go n.deliver(url, body)
+	}
+}
+
+// deliver POSTs body to url, retrying with exponential backoff up to
+// MaxAttempts times.
+func (n *Notifier) deliver(url string, body []byte) {
+	var lastErr error
+	for attempt := 0; attempt < n.config.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(n.config.BaseBackoff << (attempt - 1))
+		}
+
+		if err := n.send(url, body); err != nil {
+			lastErr = err
+			continue
+		}
+		return
+	}
+
+	log.Printf("webhook: giving up delivering to %s after %d attempts: %v", url, n.config.MaxAttempts, lastErr)
+}
+
+func (n *Notifier) send(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.Secret != "" {
+		req.Header.Set("X-TermIdle-Signature", sign(n.config.Secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("received status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of body keyed by secret.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNotifySignsAndDeliversEvent(t *testing.T) {
+	var received atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received.Store(struct {
+			body string
+			sig  string
+		}{string(body), r.Header.Get("X-TermIdle-Signature")})
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{URLs: []string{srv.URL}, Secret: "s3cr3t"})
+	n.Notify(Event{Type: EventPlayerLevelUp, Data: map[string]any{"level": 50}})
+
+	var got struct {
+		body string
+		sig  string
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if v := received.Load(); v != nil {
+			got = v.(struct {
+				body string
+				sig  string
+			})
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got.body == "" {
+		t.Fatal("webhook was not delivered in time")
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(got.body), &event); err != nil {
+		t.Fatalf("failed to decode delivered body: %v", err)
+	}
+	if event.Type != EventPlayerLevelUp {
+		t.Errorf("Type = %q, want %q", event.Type, EventPlayerLevelUp)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte(got.body))
+	want := hex.EncodeToString(mac.Sum(nil))
+	if got.sig != want {
+		t.Errorf("signature = %q, want %q", got.sig, want)
+	}
+}
+
+func TestNotifyRetriesOnFailure(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{URLs: []string{srv.URL}, MaxAttempts: 3, BaseBackoff: time.Millisecond})
+	n.Notify(Event{Type: EventNewLeaderboardLeader})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && attempts.Load() < 3 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := attempts.Load(); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
+func TestNotifyWithNoURLsIsNoop(t *testing.T) {
+	n := NewNotifier(Config{})
+	n.Notify(Event{Type: EventPlayerLevelUp})
+}